@@ -1,8 +1,8 @@
 package scheduler0_go_client
 
 // CreateAccount creates a new account
-func (c *Client) CreateAccount(body *AccountCreateRequestBody) (*AccountResponse, error) {
-	req, err := c.newRequest("POST", "/accounts", body)
+func (c *Client) CreateAccount(body *AccountCreateRequestBody, opts ...RequestOption) (*AccountResponse, error) {
+	req, err := c.newRequest("POST", "/accounts", body, opts...)
 	if err != nil {
 		return nil, err
 	}
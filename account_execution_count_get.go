@@ -4,8 +4,8 @@ import "fmt"
 
 // GetAccountExecutionCount retrieves the execution count for an account
 // accountID is used both in the URL path and as the X-Account-ID header for authentication
-func (c *Client) GetAccountExecutionCount(accountID string) (*AccountExecutionCountResponse, error) {
-	req, err := c.newRequest("GET", fmt.Sprintf("/accounts/%s/execution-count", accountID), nil, accountID)
+func (c *Client) GetAccountExecutionCount(accountID string, opts ...RequestOption) (*AccountExecutionCountResponse, error) {
+	req, err := c.newRequest("GET", fmt.Sprintf("/accounts/%s/execution-count", accountID), nil, append([]RequestOption{WithAccount(accountID)}, opts...)...)
 	if err != nil {
 		return nil, err
 	}
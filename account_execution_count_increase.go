@@ -4,11 +4,11 @@ import "fmt"
 
 // IncreaseAccountExecutionCount increases the execution count for an account
 // accountID is used both in the URL path and as the X-Account-ID header for authentication
-func (c *Client) IncreaseAccountExecutionCount(accountID string, count uint64) (*AccountExecutionCountIncreaseResponse, error) {
+func (c *Client) IncreaseAccountExecutionCount(accountID string, count uint64, opts ...RequestOption) (*AccountExecutionCountIncreaseResponse, error) {
 	body := map[string]uint64{
 		"count": count,
 	}
-	req, err := c.newRequest("PUT", fmt.Sprintf("/accounts/%s/execution-count", accountID), body, accountID)
+	req, err := c.newRequest("PUT", fmt.Sprintf("/accounts/%s/execution-count", accountID), body, append([]RequestOption{WithAccount(accountID)}, opts...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -3,8 +3,8 @@ package scheduler0_go_client
 import "fmt"
 
 // AddFeatureToAccount adds a feature to an account
-func (c *Client) AddFeatureToAccount(accountID string, body *FeatureRequest) (*FeatureRequestResponse, error) {
-	req, err := c.newRequest("PUT", fmt.Sprintf("/accounts/%s/feature", accountID), body, accountID)
+func (c *Client) AddFeatureToAccount(accountID string, body *FeatureRequest, opts ...RequestOption) (*FeatureRequestResponse, error) {
+	req, err := c.newRequest("PUT", fmt.Sprintf("/accounts/%s/feature", accountID), body, append([]RequestOption{WithAccount(accountID)}, opts...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -18,8 +18,8 @@ func (c *Client) AddFeatureToAccount(accountID string, body *FeatureRequest) (*F
 }
 
 // RemoveFeatureFromAccount removes a feature from an account
-func (c *Client) RemoveFeatureFromAccount(accountID string, body *FeatureRequest) error {
-	req, err := c.newRequest("DELETE", fmt.Sprintf("/accounts/%s/feature", accountID), body, accountID)
+func (c *Client) RemoveFeatureFromAccount(accountID string, body *FeatureRequest, opts ...RequestOption) error {
+	req, err := c.newRequest("DELETE", fmt.Sprintf("/accounts/%s/feature", accountID), body, append([]RequestOption{WithAccount(accountID)}, opts...)...)
 	if err != nil {
 		return err
 	}
@@ -28,8 +28,8 @@ func (c *Client) RemoveFeatureFromAccount(accountID string, body *FeatureRequest
 }
 
 // AddAllFeaturesToAccount adds all features to an account
-func (c *Client) AddAllFeaturesToAccount(accountID string) error {
-	req, err := c.newRequest("PUT", fmt.Sprintf("/accounts/%s/features/all", accountID), nil, accountID)
+func (c *Client) AddAllFeaturesToAccount(accountID string, opts ...RequestOption) error {
+	req, err := c.newRequest("PUT", fmt.Sprintf("/accounts/%s/features/all", accountID), nil, append([]RequestOption{WithAccount(accountID)}, opts...)...)
 	if err != nil {
 		return err
 	}
@@ -38,8 +38,8 @@ func (c *Client) AddAllFeaturesToAccount(accountID string) error {
 }
 
 // RemoveAllFeaturesFromAccount removes all features from an account
-func (c *Client) RemoveAllFeaturesFromAccount(accountID string) error {
-	req, err := c.newRequest("DELETE", fmt.Sprintf("/accounts/%s/features/all", accountID), nil, accountID)
+func (c *Client) RemoveAllFeaturesFromAccount(accountID string, opts ...RequestOption) error {
+	req, err := c.newRequest("DELETE", fmt.Sprintf("/accounts/%s/features/all", accountID), nil, append([]RequestOption{WithAccount(accountID)}, opts...)...)
 	if err != nil {
 		return err
 	}
@@ -3,8 +3,8 @@ package scheduler0_go_client
 import "fmt"
 
 // GetAccount retrieves a single account by ID
-func (c *Client) GetAccount(id string) (*AccountResponse, error) {
-	req, err := c.newRequest("GET", fmt.Sprintf("/accounts/%s", id), nil)
+func (c *Client) GetAccount(id string, opts ...RequestOption) (*AccountResponse, error) {
+	req, err := c.newRequest("GET", fmt.Sprintf("/accounts/%s", id), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
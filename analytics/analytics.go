@@ -0,0 +1,113 @@
+// Package analytics builds execution reports on top of the Scheduler0 analytics endpoints.
+//
+// GetDateRangeAnalytics returns per-minute points in UTC for a single range. This package
+// fetches consecutive ranges, merges them and re-buckets the points into hourly, daily or
+// weekly windows in a caller-chosen timezone.
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	scheduler0 "github.com/scheduler0/scheduler0-go-client"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04:05"
+)
+
+// Source is the subset of the client used to fetch analytics; *scheduler0.Client implements it
+type Source interface {
+	GetDateRangeAnalytics(params scheduler0.GetDateRangeAnalyticsParams, opts ...scheduler0.RequestOption) (*scheduler0.DateRangeAnalyticsAPIResponse, error)
+	GetExecutionTotals(accountID int64, opts ...scheduler0.RequestOption) (*scheduler0.ExecutionTotalsAPIResponse, error)
+}
+
+// Point is a single per-minute analytics point
+type Point struct {
+	Start     time.Time `json:"start"`
+	Scheduled uint64    `json:"scheduled"`
+	Success   uint64    `json:"success"`
+	Failed    uint64    `json:"failed"`
+}
+
+// FetchPoints fetches per-minute points for accountID covering [from, to)
+// Consecutive ranges are requested until to is reached; overlapping minutes keep the latest value.
+func FetchPoints(ctx context.Context, src Source, accountID int64, from, to time.Time) ([]Point, error) {
+	// A zero from would walk day by day from year 1
+	if from.IsZero() {
+		return nil, errors.New("analytics range has no start")
+	}
+	from = from.UTC().Truncate(time.Minute)
+	to = to.UTC()
+
+	byMinute := map[time.Time]Point{}
+	for start := from; start.Before(to); {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result, err := src.GetDateRangeAnalytics(scheduler0.GetDateRangeAnalyticsParams{
+			StartDate: start.Format(dateLayout),
+			StartTime: start.Format(timeLayout),
+			AccountID: accountID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, raw := range result.Data.Points {
+			point, err := parsePoint(raw)
+			if err != nil {
+				return nil, err
+			}
+			if point.Start.Before(from) || !point.Start.Before(to) {
+				continue
+			}
+			byMinute[point.Start] = point
+		}
+
+		end, err := parseDateTime(result.Data.EndDate, result.Data.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid analytics range end: %w", err)
+		}
+		next := end.Add(time.Second).Truncate(time.Second)
+		if !next.After(start) {
+			return nil, fmt.Errorf("analytics range starting %s did not advance", start.Format(time.RFC3339))
+		}
+		start = next
+	}
+
+	points := make([]Point, 0, len(byMinute))
+	for _, point := range byMinute {
+		points = append(points, point)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Start.Before(points[j].Start) })
+	return points, nil
+}
+
+func parsePoint(raw scheduler0.DateRangeAnalyticsPoint) (Point, error) {
+	start, err := parseDateTime(raw.Date, raw.Time)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid analytics point: %w", err)
+	}
+	return Point{
+		Start:     start,
+		Scheduled: raw.Scheduled,
+		Success:   raw.Success,
+		Failed:    raw.Failed,
+	}, nil
+}
+
+// parseDateTime parses the UTC date and time pair used by the analytics endpoint
+func parseDateTime(date, clock string) (time.Time, error) {
+	for _, layout := range []string{dateLayout + " " + timeLayout, dateLayout + " 15:04"} {
+		if t, err := time.Parse(layout, date+" "+clock); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date and time %q %q", date, clock)
+}
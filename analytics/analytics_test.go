@@ -0,0 +1,229 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	scheduler0 "github.com/scheduler0/scheduler0-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSource serves one day of points per call, like the analytics endpoint
+type fakeSource struct {
+	points []scheduler0.DateRangeAnalyticsPoint
+	calls  []scheduler0.GetDateRangeAnalyticsParams
+}
+
+func (f *fakeSource) GetDateRangeAnalytics(params scheduler0.GetDateRangeAnalyticsParams, opts ...scheduler0.RequestOption) (*scheduler0.DateRangeAnalyticsAPIResponse, error) {
+	f.calls = append(f.calls, params)
+	start, _ := time.Parse("2006-01-02 15:04:05", params.StartDate+" "+params.StartTime)
+	end := start.Add(24*time.Hour - time.Second)
+
+	result := &scheduler0.DateRangeAnalyticsAPIResponse{Success: true}
+	result.Data.StartDate = params.StartDate
+	result.Data.StartTime = params.StartTime
+	result.Data.EndDate = end.Format("2006-01-02")
+	result.Data.EndTime = end.Format("15:04:05")
+	for _, point := range f.points {
+		at, _ := time.Parse("2006-01-02 15:04:05", point.Date+" "+point.Time)
+		if !at.Before(start) && !at.After(end) {
+			result.Data.Points = append(result.Data.Points, point)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeSource) GetExecutionTotals(accountID int64, opts ...scheduler0.RequestOption) (*scheduler0.ExecutionTotalsAPIResponse, error) {
+	result := &scheduler0.ExecutionTotalsAPIResponse{Success: true}
+	result.Data.Scheduled = 1000
+	result.Data.Success = 900
+	result.Data.Failed = 100
+	return result, nil
+}
+
+func TestGenerateReport(t *testing.T) {
+	src := &fakeSource{points: []scheduler0.DateRangeAnalyticsPoint{
+		{Date: "2025-01-01", Time: "03:00:00", Scheduled: 10, Success: 10},
+		{Date: "2025-01-01", Time: "15:00:00", Scheduled: 20, Success: 18, Failed: 2},
+		{Date: "2025-01-02", Time: "03:00:00", Scheduled: 30, Success: 15, Failed: 15},
+	}}
+
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	report, err := GenerateReport(context.Background(), src, ReportOptions{
+		AccountID:     123,
+		From:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:            time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		Granularity:   Daily,
+		Location:      newYork,
+		TopN:          1,
+		LifetimeTotal: true,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, src.calls, 2)
+	assert.Equal(t, "2025-01-02", src.calls[1].StartDate)
+
+	// 03:00 UTC falls on the previous day in New York
+	assert.Len(t, report.Buckets, 2)
+	assert.Equal(t, 31, report.Buckets[0].Start.Day())
+	assert.Equal(t, uint64(10), report.Buckets[0].Scheduled)
+	assert.Equal(t, uint64(50), report.Buckets[1].Scheduled)
+	assert.Equal(t, uint64(60), report.Totals.Scheduled)
+	assert.InDelta(t, 43.0/60.0, report.Totals.SuccessRate, 0.0001)
+	assert.Greater(t, report.FailureRateTrend, 0.0)
+	assert.Len(t, report.BusiestWindows, 1)
+	assert.Equal(t, uint64(50), report.BusiestWindows[0].Scheduled)
+	assert.Equal(t, uint64(1000), report.Lifetime.Scheduled)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteCSV(&buf, report))
+	rows, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, csvHeader, rows[0])
+
+	buf.Reset()
+	assert.NoError(t, WriteJSON(&buf, report))
+	assert.Contains(t, buf.String(), `"granularity": "daily"`)
+}
+
+func TestGenerateReportDefaultsFrom(t *testing.T) {
+	src := &fakeSource{}
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := GenerateReport(context.Background(), src, ReportOptions{AccountID: 123, To: to})
+	assert.NoError(t, err)
+	assert.Len(t, src.calls, 30)
+	assert.Equal(t, "2025-01-02", src.calls[0].StartDate)
+
+	_, err = FetchPoints(context.Background(), src, 123, time.Time{}, to)
+	assert.Error(t, err)
+}
+
+func TestFailureRateTrendUsesBucketTimes(t *testing.T) {
+	day := func(n int) Bucket {
+		start := time.Date(2025, 1, n, 0, 0, 0, 0, time.UTC)
+		return Bucket{Start: start, End: start.AddDate(0, 0, 1)}
+	}
+	first, gap, last := day(1), day(2), day(10)
+	first.Success, first.Failed = 10, 0
+	last.Success, last.Failed = 1, 9
+
+	// The empty bucket on day 2 is skipped; day 10 is still nine days after day 1
+	assert.InDelta(t, 0.1, failureRateTrend([]Bucket{first, gap, last}), 0.0001)
+}
+
+func TestRebucketWeekly(t *testing.T) {
+	points := []Point{
+		{Start: time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC), Scheduled: 1},  // Monday
+		{Start: time.Date(2025, 1, 12, 10, 0, 0, 0, time.UTC), Scheduled: 2}, // Sunday
+		{Start: time.Date(2025, 1, 13, 10, 0, 0, 0, time.UTC), Scheduled: 3}, // next Monday
+	}
+	buckets := Rebucket(points, Weekly, time.UTC)
+	assert.Len(t, buckets, 2)
+	assert.Equal(t, uint64(3), buckets[0].Scheduled)
+	assert.Equal(t, time.Monday, buckets[1].Start.Weekday())
+}
+
+func TestDetectorFlagsFailureSpike(t *testing.T) {
+	detector := NewDetector(DetectorOptions{WarmUp: 30})
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 60; i++ {
+		failed := uint64(i % 3) // 0-2% failures
+		alert := detector.Observe(Point{Start: start.Add(time.Duration(i) * time.Minute), Scheduled: 100, Success: 100 - failed, Failed: failed})
+		assert.Nil(t, alert)
+	}
+
+	alert := detector.Observe(Point{Start: start.Add(60 * time.Minute), Scheduled: 100, Success: 40, Failed: 60})
+	if assert.NotNil(t, alert) {
+		assert.InDelta(t, 0.6, alert.FailureRate, 0.0001)
+		assert.Greater(t, alert.ZScore, 3.0)
+	}
+
+	// Cooldown suppresses a second alert straight after
+	assert.Nil(t, detector.Observe(Point{Start: start.Add(61 * time.Minute), Scheduled: 100, Success: 40, Failed: 60}))
+	// Too few executions to judge
+	assert.Nil(t, detector.Observe(Point{Start: start.Add(90 * time.Minute), Scheduled: 2, Failed: 2}))
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received Alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	var called int
+	notifier := MultiNotifier{
+		WebhookNotifier{URL: server.URL, Headers: map[string]string{"X-Token": "secret"}, HTTPClient: server.Client()},
+		NotifierFunc(func(ctx context.Context, alert Alert) error { called++; return nil }),
+	}
+	err := notifier.Notify(context.Background(), Alert{AccountID: 123, FailureRate: 0.5})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(123), received.AccountID)
+	assert.Equal(t, 1, called)
+}
+
+// minutePoints returns count points of 100 scheduled executions with 1% failures from start
+func minutePoints(start time.Time, count int) []scheduler0.DateRangeAnalyticsPoint {
+	var points []scheduler0.DateRangeAnalyticsPoint
+	for i := 0; i < count; i++ {
+		at := start.Add(time.Duration(i) * time.Minute)
+		points = append(points, scheduler0.DateRangeAnalyticsPoint{
+			Date: at.Format("2006-01-02"), Time: at.Format("15:04:05"), Scheduled: 100, Success: 99, Failed: 1,
+		})
+	}
+	return points
+}
+
+func TestMonitorWaitsForLateMinutes(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	src := &fakeSource{points: minutePoints(start, 31)}
+	var alerts []Alert
+	monitor := &Monitor{
+		Source:   src,
+		Detector: NewDetector(DetectorOptions{WarmUp: 10}),
+		Notifier: NotifierFunc(func(ctx context.Context, alert Alert) error { alerts = append(alerts, alert); return nil }),
+	}
+
+	// Minute 30 has not settled yet, so its partial counts are not evaluated
+	next := monitor.poll(context.Background(), start, start.Add(32*time.Minute+30*time.Second))
+	assert.Equal(t, start.Add(30*time.Minute), next)
+	assert.Empty(t, alerts)
+
+	// Failures aggregated into minute 30 late are seen once it settles
+	src.points[30].Success, src.points[30].Failed = 40, 60
+	next = monitor.poll(context.Background(), next, start.Add(33*time.Minute))
+	assert.Equal(t, start.Add(31*time.Minute), next)
+	if assert.Len(t, alerts, 1) {
+		assert.Equal(t, start.Add(30*time.Minute), alerts[0].At)
+	}
+}
+
+func TestMonitorDefaultsToLogNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	now := time.Now().UTC().Truncate(time.Minute)
+	src := &fakeSource{points: minutePoints(now.Add(-60*time.Minute), 55)}
+	src.points[54].Success, src.points[54].Failed = 40, 60
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	monitor := &Monitor{Source: src, Baseline: time.Hour}
+	assert.ErrorIs(t, monitor.Run(ctx), context.DeadlineExceeded)
+	assert.Contains(t, buf.String(), "execution failure rate 60.0%")
+}
@@ -0,0 +1,292 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+)
+
+// Alert describes a failure-rate spike
+type Alert struct {
+	AccountID   int64     `json:"accountId"`
+	At          time.Time `json:"at"`
+	FailureRate float64   `json:"failureRate"`
+	Baseline    float64   `json:"baseline"`
+	StdDev      float64   `json:"stdDev"`
+	ZScore      float64   `json:"zScore"`
+	Scheduled   uint64    `json:"scheduled"`
+	Failed      uint64    `json:"failed"`
+	Message     string    `json:"message"`
+}
+
+// Notifier delivers alerts
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// NotifierFunc adapts a function to Notifier
+type NotifierFunc func(ctx context.Context, alert Alert) error
+
+// Notify calls f
+func (f NotifierFunc) Notify(ctx context.Context, alert Alert) error {
+	return f(ctx, alert)
+}
+
+// LogNotifier writes alerts to Logger, or the standard logger if nil
+type LogNotifier struct {
+	Logger *log.Logger
+}
+
+// Notify logs the alert message
+func (n LogNotifier) Notify(_ context.Context, alert Alert) error {
+	if n.Logger != nil {
+		n.Logger.Print(alert.Message)
+	} else {
+		log.Print(alert.Message)
+	}
+	return nil
+}
+
+// WebhookNotifier POSTs alerts as JSON to URL
+type WebhookNotifier struct {
+	URL        string
+	Headers    map[string]string
+	HTTPClient *http.Client
+}
+
+// Notify posts the alert and fails on non-2xx responses
+func (n WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range n.Headers {
+		req.Header.Set(key, value)
+	}
+
+	client := n.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// MultiNotifier sends each alert to every notifier, returning the first error
+type MultiNotifier []Notifier
+
+// Notify delivers the alert to all notifiers
+func (m MultiNotifier) Notify(ctx context.Context, alert Alert) error {
+	var first error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, alert); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// DetectorOptions tunes the anomaly detector
+type DetectorOptions struct {
+	Alpha          float64       // EWMA smoothing factor in (0, 1] (defaults to 0.1)
+	Threshold      float64       // Z-score that triggers an alert (defaults to 3)
+	MinScheduled   uint64        // Minutes with fewer scheduled executions are ignored (defaults to 10)
+	MinFailureRate float64       // Failure rates below this never alert (defaults to 0.05)
+	MinStdDev      float64       // Lower bound on the baseline deviation (defaults to 0.01)
+	WarmUp         int           // Minutes observed before alerts are raised (defaults to 30)
+	Cooldown       time.Duration // Minimum time between alerts (defaults to 15 minutes)
+}
+
+func (opts DetectorOptions) withDefaults() DetectorOptions {
+	if opts.Alpha <= 0 || opts.Alpha > 1 {
+		opts.Alpha = 0.1
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = 3
+	}
+	if opts.MinScheduled == 0 {
+		opts.MinScheduled = 10
+	}
+	if opts.MinFailureRate <= 0 {
+		opts.MinFailureRate = 0.05
+	}
+	if opts.MinStdDev <= 0 {
+		opts.MinStdDev = 0.01
+	}
+	if opts.WarmUp <= 0 {
+		opts.WarmUp = 30
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 15 * time.Minute
+	}
+	return opts
+}
+
+// Detector tracks an EWMA baseline of the per-minute failure rate and flags spikes by z-score
+type Detector struct {
+	opts      DetectorOptions
+	mean      float64
+	variance  float64
+	samples   int
+	lastAlert time.Time
+}
+
+// NewDetector creates a detector with opts
+func NewDetector(opts DetectorOptions) *Detector {
+	return &Detector{opts: opts.withDefaults()}
+}
+
+// Observe feeds a per-minute point into the baseline and returns an alert if it is a spike
+// The failure rate is Failed / Scheduled, falling back to completed executions if none were scheduled.
+func (d *Detector) Observe(point Point) *Alert {
+	total := point.Scheduled
+	if total == 0 {
+		total = point.Success + point.Failed
+	}
+	if total < d.opts.MinScheduled {
+		return nil
+	}
+	failureRate := math.Min(1, float64(point.Failed)/float64(total))
+
+	baseline := d.mean
+	stdDev := math.Max(math.Sqrt(d.variance), d.opts.MinStdDev)
+	zScore := (failureRate - baseline) / stdDev
+	warm := d.samples >= d.opts.WarmUp
+
+	// Update the EWMA mean and variance
+	if d.samples == 0 {
+		d.mean = failureRate
+	} else {
+		diff := failureRate - d.mean
+		increment := d.opts.Alpha * diff
+		d.mean += increment
+		d.variance = (1 - d.opts.Alpha) * (d.variance + diff*increment)
+	}
+	d.samples++
+
+	if !warm || zScore < d.opts.Threshold || failureRate < d.opts.MinFailureRate {
+		return nil
+	}
+	if !d.lastAlert.IsZero() && point.Start.Sub(d.lastAlert) < d.opts.Cooldown {
+		return nil
+	}
+	d.lastAlert = point.Start
+
+	return &Alert{
+		At:          point.Start,
+		FailureRate: failureRate,
+		Baseline:    baseline,
+		StdDev:      stdDev,
+		ZScore:      zScore,
+		Scheduled:   point.Scheduled,
+		Failed:      point.Failed,
+		Message: fmt.Sprintf("execution failure rate %.1f%% at %s is %.1f standard deviations above the %.1f%% baseline",
+			failureRate*100, point.Start.Format(time.RFC3339), zScore, baseline*100),
+	}
+}
+
+// Monitor polls analytics for an account and notifies on failure spikes
+type Monitor struct {
+	Source    Source
+	AccountID int64
+	Detector  *Detector
+	Notifier  Notifier      // Defaults to LogNotifier with the standard logger
+	Interval  time.Duration // Polling interval (defaults to 1 minute)
+	Baseline  time.Duration // History loaded to warm up the detector on start (defaults to 2 hours)
+	// Settle is how long after a minute ends it is evaluated, so executions aggregated into it
+	// late are counted (defaults to 2 minutes)
+	Settle time.Duration
+	// OnError is called with fetch and notification errors; Run keeps going after them
+	OnError func(err error)
+}
+
+// Run warms up the detector with recent history and then polls until ctx is cancelled
+func (m *Monitor) Run(ctx context.Context) error {
+	interval := m.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	baseline := m.Baseline
+	if baseline <= 0 {
+		baseline = 2 * time.Hour
+	}
+	if m.Detector == nil {
+		m.Detector = NewDetector(DetectorOptions{})
+	}
+	if m.Notifier == nil {
+		m.Notifier = LogNotifier{}
+	}
+
+	next := time.Now().UTC().Add(-baseline).Truncate(time.Minute)
+	for {
+		next = m.poll(ctx, next, time.Now().UTC())
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// poll evaluates the minutes from next that ended at least Settle before now and returns the
+// minute the following poll starts at
+// Minutes that have not settled are fetched again by later polls, and a failed fetch is retried
+// from next, so every minute is evaluated exactly once.
+func (m *Monitor) poll(ctx context.Context, next, now time.Time) time.Time {
+	settle := m.Settle
+	if settle <= 0 {
+		settle = 2 * time.Minute
+	}
+	until := now.Add(-settle).Truncate(time.Minute)
+	if !until.After(next) {
+		return next
+	}
+
+	points, err := FetchPoints(ctx, m.Source, m.AccountID, next, until)
+	if err != nil {
+		if ctx.Err() == nil {
+			m.reportError(err)
+		}
+		return next
+	}
+	for _, point := range points {
+		if alert := m.Detector.Observe(point); alert != nil {
+			alert.AccountID = m.AccountID
+			if err := m.Notifier.Notify(ctx, *alert); err != nil {
+				m.reportError(err)
+			}
+		}
+	}
+	return until
+}
+
+func (m *Monitor) reportError(err error) {
+	if m.OnError != nil {
+		m.OnError(err)
+	}
+}
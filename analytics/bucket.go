@@ -0,0 +1,93 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+)
+
+// Granularity is the width of a report bucket
+type Granularity string
+
+const (
+	Hourly Granularity = "hourly"
+	Daily  Granularity = "daily"
+	Weekly Granularity = "weekly"
+)
+
+// Bucket aggregates points over one window
+type Bucket struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Scheduled uint64    `json:"scheduled"`
+	Success   uint64    `json:"success"`
+	Failed    uint64    `json:"failed"`
+}
+
+// SuccessRate is Success divided by completed (successful plus failed) executions
+func (b Bucket) SuccessRate() float64 {
+	return rate(b.Success, b.Success+b.Failed)
+}
+
+// FailureRate is Failed divided by completed (successful plus failed) executions
+func (b Bucket) FailureRate() float64 {
+	return rate(b.Failed, b.Success+b.Failed)
+}
+
+func rate(part, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
+
+// Rebucket groups points into windows of granularity aligned to loc
+// Days start at local midnight and weeks on Monday, so DST transitions produce 23 or 25 hour days.
+func Rebucket(points []Point, granularity Granularity, loc *time.Location) []Bucket {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	byStart := map[time.Time]*Bucket{}
+	for _, point := range points {
+		start := bucketStart(point.Start.In(loc), granularity)
+		bucket, ok := byStart[start]
+		if !ok {
+			bucket = &Bucket{Start: start, End: bucketEnd(start, granularity)}
+			byStart[start] = bucket
+		}
+		bucket.Scheduled += point.Scheduled
+		bucket.Success += point.Success
+		bucket.Failed += point.Failed
+	}
+
+	buckets := make([]Bucket, 0, len(byStart))
+	for _, bucket := range byStart {
+		buckets = append(buckets, *bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Start.Before(buckets[j].Start) })
+	return buckets
+}
+
+func bucketStart(t time.Time, granularity Granularity) time.Time {
+	year, month, day := t.Date()
+	switch granularity {
+	case Hourly:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location())
+	case Weekly:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+func bucketEnd(start time.Time, granularity Granularity) time.Time {
+	switch granularity {
+	case Hourly:
+		return start.Add(time.Hour)
+	case Weekly:
+		return start.AddDate(0, 0, 7)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
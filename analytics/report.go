@@ -0,0 +1,199 @@
+package analytics
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	scheduler0 "github.com/scheduler0/scheduler0-go-client"
+)
+
+// ReportOptions configures GenerateReport and BuildReport
+type ReportOptions struct {
+	AccountID     int64          // Account to report on (0 uses the client default)
+	From          time.Time      // Start of the reported window (defaults to 30 days before To)
+	To            time.Time      // End of the reported window (defaults to now)
+	Granularity   Granularity    // Bucket width (defaults to Daily)
+	Location      *time.Location // Timezone buckets are aligned to (defaults to UTC)
+	TopN          int            // Number of busiest windows to report (defaults to 5)
+	LifetimeTotal bool           // Also fetch lifetime counters with GetExecutionTotals
+}
+
+// Totals summarises a set of executions
+type Totals struct {
+	Scheduled   uint64  `json:"scheduled"`
+	Success     uint64  `json:"success"`
+	Failed      uint64  `json:"failed"`
+	SuccessRate float64 `json:"successRate"`
+	FailureRate float64 `json:"failureRate"`
+}
+
+// Report is the result of GenerateReport
+type Report struct {
+	AccountID   int64       `json:"accountId"`
+	From        time.Time   `json:"from"`
+	To          time.Time   `json:"to"`
+	Timezone    string      `json:"timezone"`
+	Granularity Granularity `json:"granularity"`
+	Totals      Totals      `json:"totals"`
+	Lifetime    *Totals     `json:"lifetime,omitempty"`
+	Buckets     []Bucket    `json:"buckets"`
+	// FailureRateTrend is the least-squares slope of the failure rate per bucket width, measured
+	// over time so buckets without executions do not compress it; positive values mean failures
+	// are becoming more frequent.
+	FailureRateTrend float64  `json:"failureRateTrend"`
+	BusiestWindows   []Bucket `json:"busiestWindows"`
+}
+
+// GenerateReport fetches analytics for the window in opts and builds a report
+func GenerateReport(ctx context.Context, src Source, opts ReportOptions) (*Report, error) {
+	opts = opts.withDefaults()
+
+	points, err := FetchPoints(ctx, src, opts.AccountID, opts.From, opts.To)
+	if err != nil {
+		return nil, err
+	}
+	report := BuildReport(points, opts)
+
+	if opts.LifetimeTotal {
+		result, err := src.GetExecutionTotals(opts.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		lifetime := newTotals(result.Data.Scheduled, result.Data.Success, result.Data.Failed)
+		report.Lifetime = &lifetime
+	}
+	return report, nil
+}
+
+// BuildReport builds a report from already fetched points
+func BuildReport(points []Point, opts ReportOptions) *Report {
+	opts = opts.withDefaults()
+
+	buckets := Rebucket(points, opts.Granularity, opts.Location)
+	var scheduled, success, failed uint64
+	for _, bucket := range buckets {
+		scheduled += bucket.Scheduled
+		success += bucket.Success
+		failed += bucket.Failed
+	}
+
+	busiest := append([]Bucket(nil), buckets...)
+	sort.SliceStable(busiest, func(i, j int) bool { return busiest[i].Scheduled > busiest[j].Scheduled })
+	if len(busiest) > opts.TopN {
+		busiest = busiest[:opts.TopN]
+	}
+
+	return &Report{
+		AccountID:        opts.AccountID,
+		From:             opts.From.In(opts.Location),
+		To:               opts.To.In(opts.Location),
+		Timezone:         opts.Location.String(),
+		Granularity:      opts.Granularity,
+		Totals:           newTotals(scheduled, success, failed),
+		Buckets:          buckets,
+		FailureRateTrend: failureRateTrend(buckets),
+		BusiestWindows:   busiest,
+	}
+}
+
+func (opts ReportOptions) withDefaults() ReportOptions {
+	if opts.To.IsZero() {
+		opts.To = time.Now()
+	}
+	if opts.From.IsZero() {
+		opts.From = opts.To.AddDate(0, 0, -30)
+	}
+	if opts.Granularity == "" {
+		opts.Granularity = Daily
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	if opts.TopN <= 0 {
+		opts.TopN = 5
+	}
+	return opts
+}
+
+func newTotals(scheduled, success, failed uint64) Totals {
+	return Totals{
+		Scheduled:   scheduled,
+		Success:     success,
+		Failed:      failed,
+		SuccessRate: rate(success, success+failed),
+		FailureRate: rate(failed, success+failed),
+	}
+}
+
+// failureRateTrend fits a line through the failure rate of buckets that completed executions
+// x is the bucket's start in bucket widths from the first bucket, so gaps keep their length.
+func failureRateTrend(buckets []Bucket) float64 {
+	if len(buckets) == 0 {
+		return 0
+	}
+	origin, width := buckets[0].Start, buckets[0].End.Sub(buckets[0].Start)
+	if width <= 0 {
+		return 0
+	}
+
+	var n, sumX, sumY, sumXY, sumXX float64
+	for _, bucket := range buckets {
+		if bucket.Success+bucket.Failed == 0 {
+			continue
+		}
+		x, y := float64(bucket.Start.Sub(origin))/float64(width), bucket.FailureRate()
+		n++
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if n < 2 || denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// WriteJSON renders the report as indented JSON
+func WriteJSON(w io.Writer, report *Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// csvHeader is the column order written by WriteCSV
+var csvHeader = []string{"start", "end", "scheduled", "success", "failed", "success_rate", "failure_rate"}
+
+// WriteCSV renders the report buckets as CSV, one row per bucket
+func WriteCSV(w io.Writer, report *Report) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, bucket := range report.Buckets {
+		row := []string{
+			bucket.Start.Format(time.RFC3339),
+			bucket.End.Format(time.RFC3339),
+			strconv.FormatUint(bucket.Scheduled, 10),
+			strconv.FormatUint(bucket.Success, 10),
+			strconv.FormatUint(bucket.Failed, 10),
+			fmt.Sprintf("%.4f", bucket.SuccessRate()),
+			fmt.Sprintf("%.4f", bucket.FailureRate()),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Ensure the client satisfies Source
+var _ Source = (*scheduler0.Client)(nil)
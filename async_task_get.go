@@ -3,8 +3,8 @@ package scheduler0_go_client
 import "fmt"
 
 // GetAsyncTask retrieves an async task by request ID
-func (c *Client) GetAsyncTask(requestID string) (*AsyncTaskResponse, error) {
-	req, err := c.newRequest("GET", fmt.Sprintf("/async-tasks/%s", requestID), nil)
+func (c *Client) GetAsyncTask(requestID string, opts ...RequestOption) (*AsyncTaskResponse, error) {
+	req, err := c.newRequest("GET", fmt.Sprintf("/async-tasks/%s", requestID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
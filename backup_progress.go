@@ -1,8 +1,8 @@
 package scheduler0_go_client
 
 // GetBackupRestoreProgress retrieves the current backup/restore progress
-func (c *Client) GetBackupRestoreProgress() (*BackupRestoreProgressResponse, error) {
-	req, err := c.newRequest("GET", "/cluster/backup-restore-progress", nil, "")
+func (c *Client) GetBackupRestoreProgress(opts ...RequestOption) (*BackupRestoreProgressResponse, error) {
+	req, err := c.newRequest("GET", "/cluster/backup-restore-progress", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1,8 +1,8 @@
 package scheduler0_go_client
 
 // BackupDatabase initiates an automatic timestamped backup
-func (c *Client) BackupDatabase() (*BackupRestoreResponse, error) {
-	req, err := c.newRequest("POST", "/cluster/backup", nil, "")
+func (c *Client) BackupDatabase(opts ...RequestOption) (*BackupRestoreResponse, error) {
+	req, err := c.newRequest("POST", "/cluster/backup", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
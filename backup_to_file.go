@@ -1,12 +1,12 @@
 package scheduler0_go_client
 
 // BackupDatabaseToFile initiates a backup to a specific file path
-func (c *Client) BackupDatabaseToFile(destPath string) (*BackupRestoreResponse, error) {
+func (c *Client) BackupDatabaseToFile(destPath string, opts ...RequestOption) (*BackupRestoreResponse, error) {
 	reqBody := BackupToFileRequest{
 		DestPath: destPath,
 	}
 
-	req, err := c.newRequest("POST", "/cluster/backup-to-file", reqBody, "")
+	req, err := c.newRequest("POST", "/cluster/backup-to-file", reqBody, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,250 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while a circuit breaker is open, or
+// while a half-open breaker already has its trial requests in flight
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // Requests are sent and failures counted
+	CircuitOpen                         // Requests fail fast with ErrCircuitOpen
+	CircuitHalfOpen                     // Trial requests decide whether to close or reopen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitScope selects which requests share a circuit breaker
+type CircuitScope int
+
+const (
+	CircuitPerNode      CircuitScope = iota // One breaker per host, named like "scheduler0.example.com:443"
+	CircuitPerOperation                     // One breaker per operation, named like "POST /jobs", see WithOperationLimit
+)
+
+// CircuitBreakerConfig configures WithCircuitBreaker
+type CircuitBreakerConfig struct {
+	Scope            CircuitScope
+	FailureThreshold int           // Consecutive failures that open the breaker (defaults to 5)
+	OpenTimeout      time.Duration // Time an open breaker rejects requests before trying again (defaults to 30s)
+	HalfOpenRequests int           // Trial requests that must succeed to close the breaker (defaults to 1)
+	// IsFailure reports whether a request outcome counts as a failure (defaults to transport
+	// errors and 5xx responses); requests cut short by the caller's context, whether cancelled
+	// or past its deadline or WithTimeout, never count
+	IsFailure func(resp *http.Response, err error) bool
+	// OnStateChange, if set, is called after a breaker changes state, e.g. to alert when it opens
+	OnStateChange func(name string, from, to CircuitState)
+}
+
+// WithCircuitBreaker makes requests fail fast with ErrCircuitOpen after repeated failures
+// Once FailureThreshold consecutive requests failed, the breaker opens and rejects requests for
+// OpenTimeout instead of letting each wait for a network timeout. It then lets HalfOpenRequests
+// trial requests through: if they succeed the breaker closes, otherwise it opens again. Tenant
+// clients created with ForAccount share their parent's breakers.
+func WithCircuitBreaker(cfg CircuitBreakerConfig) ClientOption {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		cfg.HalfOpenRequests = 1
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = defaultIsFailure
+	}
+	return func(c *Client) {
+		c.breakers = &circuitBreakers{cfg: cfg, circuits: map[string]*circuit{}}
+	}
+}
+
+// CircuitState returns the state of the named circuit breaker
+// Breakers that have not seen a request, and clients without WithCircuitBreaker, report closed.
+func (c *Client) CircuitState(name string) CircuitState {
+	if c.breakers == nil {
+		return CircuitClosed
+	}
+	c.breakers.mu.Lock()
+	defer c.breakers.mu.Unlock()
+	if circuit, ok := c.breakers.circuits[name]; ok {
+		return circuit.state
+	}
+	return CircuitClosed
+}
+
+func defaultIsFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= 500
+}
+
+type circuitBreakers struct {
+	cfg CircuitBreakerConfig
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state      CircuitState
+	generation uint64    // Incremented on every state change
+	failures   int       // Consecutive failures while closed
+	openedAt   time.Time // When the breaker last opened
+	trials     int       // Trial requests in flight while half-open
+	successes  int       // Successful trial requests while half-open
+}
+
+// circuitTransition is a state change reported to OnStateChange once the lock is released
+type circuitTransition struct {
+	name     string
+	from, to CircuitState
+}
+
+// circuitName names the breaker req goes through
+func (c *Client) circuitName(req *http.Request) string {
+	if c.breakers.cfg.Scope == CircuitPerOperation {
+		return c.operationName(req)
+	}
+	return req.URL.Host
+}
+
+// circuitCall is a request admitted by a circuit breaker; a nil call records nothing
+type circuitCall struct {
+	breakers   *circuitBreakers
+	name       string
+	generation uint64 // Generation of the circuit when the request was admitted
+	trial      bool   // Half-open trial request
+}
+
+// allow admits a request through the named breaker, or returns ErrCircuitOpen
+func (b *circuitBreakers) allow(name string) (*circuitCall, error) {
+	var transitions []circuitTransition
+	defer func() { b.notify(transitions) }()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb, ok := b.circuits[name]
+	if !ok {
+		cb = &circuit{}
+		b.circuits[name] = cb
+	}
+
+	if cb.state == CircuitOpen {
+		retryAt := cb.openedAt.Add(b.cfg.OpenTimeout)
+		if time.Now().Before(retryAt) {
+			return nil, fmt.Errorf("%w: %s, retrying after %s", ErrCircuitOpen, name, retryAt.Format(time.RFC3339))
+		}
+		transitions = append(transitions, b.transition(name, cb, CircuitHalfOpen))
+	}
+	call := &circuitCall{breakers: b, name: name, generation: cb.generation}
+	if cb.state == CircuitHalfOpen {
+		if cb.trials+cb.successes >= b.cfg.HalfOpenRequests {
+			return nil, fmt.Errorf("%w: %s, trial requests in progress", ErrCircuitOpen, name)
+		}
+		cb.trials++
+		call.trial = true
+	}
+	return call, nil
+}
+
+// done records the outcome of the request sent with ctx
+// Requests that failed once ctx was cancelled or past its deadline are not counted: the caller
+// gave up on them, which says nothing about the server.
+func (call *circuitCall) done(ctx context.Context, resp *http.Response, err error) {
+	if call == nil {
+		return
+	}
+	if err != nil && ctx.Err() != nil {
+		call.cancel()
+		return
+	}
+	call.breakers.record(call, call.breakers.cfg.IsFailure(resp, err))
+}
+
+// cancel releases the trial slot of a request that was not sent or whose outcome should not count
+func (call *circuitCall) cancel() {
+	if call == nil || !call.trial {
+		return
+	}
+	b := call.breakers
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if cb := b.circuits[call.name]; cb.generation == call.generation && cb.trials > 0 {
+		cb.trials--
+	}
+}
+
+// record counts the outcome of a request admitted by allow
+// Outcomes of requests admitted before the breaker last changed state are ignored, so a slow
+// request from an earlier closed or half-open period cannot close or reopen the breaker.
+func (b *circuitBreakers) record(call *circuitCall, failed bool) {
+	var transitions []circuitTransition
+	defer func() { b.notify(transitions) }()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	name, trial := call.name, call.trial
+	cb := b.circuits[name]
+	if cb.generation != call.generation {
+		return
+	}
+	switch {
+	case cb.state == CircuitClosed && failed:
+		cb.failures++
+		if cb.failures >= b.cfg.FailureThreshold {
+			transitions = append(transitions, b.transition(name, cb, CircuitOpen))
+		}
+	case cb.state == CircuitClosed:
+		cb.failures = 0
+	case cb.state == CircuitHalfOpen && trial && failed:
+		transitions = append(transitions, b.transition(name, cb, CircuitOpen))
+	case cb.state == CircuitHalfOpen && trial:
+		cb.trials--
+		cb.successes++
+		if cb.successes >= b.cfg.HalfOpenRequests {
+			transitions = append(transitions, b.transition(name, cb, CircuitClosed))
+		}
+	}
+}
+
+// transition moves cb to state and resets its counters; b.mu must be held
+func (b *circuitBreakers) transition(name string, cb *circuit, state CircuitState) circuitTransition {
+	t := circuitTransition{name: name, from: cb.state, to: state}
+	cb.state = state
+	cb.generation++
+	cb.failures, cb.trials, cb.successes = 0, 0, 0
+	if state == CircuitOpen {
+		cb.openedAt = time.Now()
+	}
+	return t
+}
+
+func (b *circuitBreakers) notify(transitions []circuitTransition) {
+	if b.cfg.OnStateChange == nil {
+		return
+	}
+	for _, t := range transitions {
+		b.cfg.OnStateChange(t.name, t.from, t.to)
+	}
+}
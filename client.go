@@ -3,6 +3,7 @@ package scheduler0_go_client
 import (
 	"net/http"
 	"net/url"
+	"sync"
 )
 
 type Client struct {
@@ -16,6 +17,20 @@ type Client struct {
 	Password string
 	// Account ID for most endpoints
 	AccountID string
+
+	// credentials, if set, replaces the credential fields above
+	credentials CredentialsProvider
+	// pinnedAccount, if set, is the account of every request regardless of AccountID, see ForAccount
+	pinnedAccount string
+	// strictAccounts rejects requests naming conflicting accounts, see WithStrictAccountScoping
+	strictAccounts bool
+	// limits holds the limits configured with WithLimit and related options and the pause
+	// after 429 responses
+	limits *clientLimits
+	// breakers, if set, holds the circuit breakers configured with WithCircuitBreaker
+	breakers *circuitBreakers
+	// mu guards the credential fields once the client is in use
+	mu sync.RWMutex
 }
 
 func NewClient(baseURL, version string, options ...ClientOption) (*Client, error) {
@@ -28,6 +43,7 @@ func NewClient(baseURL, version string, options ...ClientOption) (*Client, error
 		BaseURL:    u,
 		HTTPClient: &http.Client{},
 		Version:    version,
+		limits:     newClientLimits(nil),
 	}
 
 	// Apply options
@@ -56,6 +72,15 @@ func WithAccountID(accountID string) ClientOption {
 	}
 }
 
+// WithStrictAccountScoping makes requests fail with ErrAccountMismatch when the WithAccount
+// override and the AccountID fields of the request body (for batches, of every item) disagree,
+// instead of silently using the override or the first item's account
+func WithStrictAccountScoping() ClientOption {
+	return func(c *Client) {
+		c.strictAccounts = true
+	}
+}
+
 // WithBasicAuth sets the username and password for basic authentication
 func WithBasicAuth(username, password string) ClientOption {
 	return func(c *Client) {
@@ -64,6 +89,22 @@ func WithBasicAuth(username, password string) ClientOption {
 	}
 }
 
+// SetAPIKey replaces the API key and secret of a client that may be in use
+// Requests built after it returns use the new credential, unless a CredentialsProvider is configured.
+func (c *Client) SetAPIKey(apiKey, apiSecret string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.APIKey = apiKey
+	c.APISecret = apiSecret
+}
+
+// apiKey returns the current API key and secret
+func (c *Client) apiKey() (string, string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.APIKey, c.APISecret
+}
+
 // Convenience functions for common use cases
 
 // NewAPIClient creates a client with API key authentication
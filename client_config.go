@@ -0,0 +1,246 @@
+package scheduler0_go_client
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by NewClientFromEnv and NewClientFromConfig, in addition to
+// EnvAPIKey, EnvAPISecret, EnvUsername and EnvPassword
+const (
+	EnvURL       = "SCHEDULER0_URL"
+	EnvVersion   = "SCHEDULER0_API_VERSION"
+	EnvAccountID = "SCHEDULER0_ACCOUNT_ID"
+	EnvProfile   = "SCHEDULER0_PROFILE"
+	EnvConfig    = "SCHEDULER0_CONFIG"
+)
+
+// defaultAPIVersion is used when no API version is configured
+const defaultAPIVersion = "v1"
+
+// ErrMissingConfigValue is returned when a required client setting is not configured
+var ErrMissingConfigValue = errors.New("missing configuration value")
+
+// ConfigProfile holds the settings of one client, e.g. one environment and account
+type ConfigProfile struct {
+	URL       string `yaml:"url" json:"url"`
+	Version   string `yaml:"version" json:"version"` // Defaults to v1
+	APIKey    string `yaml:"api_key" json:"api_key"`
+	APISecret string `yaml:"api_secret" json:"api_secret"`
+	Username  string `yaml:"username" json:"username"`
+	Password  string `yaml:"password" json:"password"`
+	AccountID string `yaml:"account_id" json:"account_id"`
+}
+
+// ClientConfig is a config file with named profiles
+//
+//	default_profile: production
+//	profiles:
+//	  production:
+//	    url: https://scheduler0.example.com
+//	    api_key: ...
+//	    api_secret: ...
+//	    account_id: "42"
+//
+// JSON files with the same keys are accepted as well.
+type ClientConfig struct {
+	DefaultProfile string                   `yaml:"default_profile" json:"default_profile"`
+	Profiles       map[string]ConfigProfile `yaml:"profiles" json:"profiles"`
+}
+
+// envNames maps ConfigProfile keys to the environment variables NewClientFromEnv reads
+var envNames = map[string]string{
+	"url":        EnvURL,
+	"api_key":    EnvAPIKey,
+	"api_secret": EnvAPISecret,
+	"username":   EnvUsername,
+	"password":   EnvPassword,
+	"account_id": EnvAccountID,
+}
+
+// NewClientFromEnv creates a client from SCHEDULER0_* environment variables
+// SCHEDULER0_URL is required, together with SCHEDULER0_API_KEY and SCHEDULER0_API_SECRET or
+// SCHEDULER0_USERNAME and SCHEDULER0_PASSWORD. SCHEDULER0_API_VERSION (defaults to v1) and
+// SCHEDULER0_ACCOUNT_ID are optional. options are applied after the environment settings.
+func NewClientFromEnv(options ...ClientOption) (*Client, error) {
+	profile := ConfigProfile{
+		URL:       os.Getenv(EnvURL),
+		Version:   os.Getenv(EnvVersion),
+		APIKey:    os.Getenv(EnvAPIKey),
+		APISecret: os.Getenv(EnvAPISecret),
+		Username:  os.Getenv(EnvUsername),
+		Password:  os.Getenv(EnvPassword),
+		AccountID: os.Getenv(EnvAccountID),
+	}
+	if err := profile.validate(func(key string) string { return envNames[key] }); err != nil {
+		return nil, fmt.Errorf("scheduler0 environment: %w", err)
+	}
+	return profile.newClient(options...)
+}
+
+// DefaultConfigPath returns SCHEDULER0_CONFIG, or ~/.scheduler0/config if it is not set
+func DefaultConfigPath() (string, error) {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".scheduler0", "config"), nil
+}
+
+// LoadClientConfig reads a YAML or JSON config file
+// An empty path reads DefaultConfigPath.
+func LoadClientConfig(path string) (*ClientConfig, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultConfigPath(); err != nil {
+			return nil, err
+		}
+	}
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// YAML is a superset of JSON, so one decoder handles both formats
+	var config ClientConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if len(config.Profiles) == 0 {
+		return nil, fmt.Errorf("config file %s defines no profiles", path)
+	}
+	return &config, nil
+}
+
+// Profile returns the named profile
+// An empty name selects SCHEDULER0_PROFILE, then DefaultProfile, then "default".
+func (cfg *ClientConfig) Profile(name string) (ConfigProfile, error) {
+	if name == "" {
+		name = os.Getenv(EnvProfile)
+	}
+	if name == "" {
+		name = cfg.DefaultProfile
+	}
+	if name == "" {
+		name = "default"
+	}
+
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(cfg.Profiles))
+		for n := range cfg.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return ConfigProfile{}, fmt.Errorf("profile %q not found, available profiles: %s", name, strings.Join(names, ", "))
+	}
+	return profile, nil
+}
+
+// NewClientFromConfig creates a client from the selected profile of a config file
+// The profile is chosen as described on ClientConfig.Profile; use NewClientFromConfigProfile to
+// name it explicitly. An empty path reads DefaultConfigPath.
+func NewClientFromConfig(path string, options ...ClientOption) (*Client, error) {
+	return NewClientFromConfigProfile(path, "", options...)
+}
+
+// NewClientFromConfigProfile creates a client from the named profile of a config file
+func NewClientFromConfigProfile(path, profileName string, options ...ClientOption) (*Client, error) {
+	config, err := LoadClientConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := config.Profile(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return profile.NewClient(options...)
+}
+
+// NewClient validates the profile and creates a client from it, applying options last
+func (p ConfigProfile) NewClient(options ...ClientOption) (*Client, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p.newClient(options...)
+}
+
+// Validate reports missing or malformed settings
+func (p ConfigProfile) Validate() error {
+	return p.validate(func(key string) string { return key })
+}
+
+// validate checks the profile, naming settings with name so errors match their source
+func (p ConfigProfile) validate(name func(key string) string) error {
+	var errs []error
+	if p.URL == "" {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrMissingConfigValue, name("url")))
+	}
+
+	hasKey := p.APIKey != "" || p.APISecret != ""
+	hasBasic := p.Username != "" || p.Password != ""
+	switch {
+	case !hasKey && !hasBasic:
+		errs = append(errs, fmt.Errorf("%w: %s and %s, or %s and %s", ErrMissingConfigValue,
+			name("api_key"), name("api_secret"), name("username"), name("password")))
+	case hasKey && p.APIKey == "":
+		errs = append(errs, fmt.Errorf("%w: %s (%s is set)", ErrMissingConfigValue, name("api_key"), name("api_secret")))
+	case hasKey && p.APISecret == "":
+		errs = append(errs, fmt.Errorf("%w: %s (%s is set)", ErrMissingConfigValue, name("api_secret"), name("api_key")))
+	case hasBasic && p.Username == "":
+		errs = append(errs, fmt.Errorf("%w: %s (%s is set)", ErrMissingConfigValue, name("username"), name("password")))
+	case hasBasic && p.Password == "":
+		errs = append(errs, fmt.Errorf("%w: %s (%s is set)", ErrMissingConfigValue, name("password"), name("username")))
+	}
+
+	if p.AccountID != "" {
+		if _, err := strconv.ParseInt(p.AccountID, 10, 64); err != nil {
+			errs = append(errs, fmt.Errorf("%s must be numeric, got %q", name("account_id"), p.AccountID))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p ConfigProfile) newClient(options ...ClientOption) (*Client, error) {
+	version := p.Version
+	if version == "" {
+		version = defaultAPIVersion
+	}
+
+	var profileOptions []ClientOption
+	if p.APIKey != "" {
+		profileOptions = append(profileOptions, WithAPIKey(p.APIKey, p.APISecret))
+	}
+	if p.Username != "" {
+		profileOptions = append(profileOptions, WithBasicAuth(p.Username, p.Password))
+	}
+	if p.AccountID != "" {
+		profileOptions = append(profileOptions, WithAccountID(p.AccountID))
+	}
+	return NewClient(p.URL, version, append(profileOptions, options...)...)
+}
+
+// Format prints the profile with APISecret and Password redacted for every verb
+func (p ConfigProfile) Format(f fmt.State, verb rune) {
+	type fields ConfigProfile
+	p.APISecret = redact(p.APISecret)
+	p.Password = redact(p.Password)
+	formatRedacted(f, verb, fields(p), "ConfigProfile")
+}
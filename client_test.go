@@ -1,11 +1,24 @@
 package scheduler0_go_client
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -1526,3 +1539,1951 @@ func TestCleanupOldExecutionLogs(t *testing.T) {
 	assert.True(t, result.Success)
 	assert.Contains(t, result.Data.Message, "cleaned up successfully")
 }
+
+func TestParseTime(t *testing.T) {
+	expected := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, value := range []string{
+		"2025-01-02T03:04:05Z",
+		"2025-01-02T03:04:05.000Z",
+		"2025-01-02 03:04:05 +0000 UTC",
+		"2025-01-02 03:04:05.000 +0000 UTC m=+0.000000001",
+		"2025-01-02 03:04:05",
+		"2025-01-02T03:04:05",
+	} {
+		parsed, err := ParseTime(value)
+		assert.NoError(t, err, value)
+		assert.True(t, expected.Equal(parsed), value)
+	}
+
+	parsed, err := ParseTime("")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsZero())
+
+	_, err = ParseTime("not a date")
+	assert.Error(t, err)
+}
+
+func TestParsedDateAccessors(t *testing.T) {
+	modified := "2025-02-01T00:00:00Z"
+	job := Job{DateCreated: "2025-01-01T00:00:00Z", DateModified: &modified}
+
+	created, err := job.ParsedDateCreated()
+	assert.NoError(t, err)
+	assert.Equal(t, 2025, created.Year())
+
+	mod, err := job.ParsedDateModified()
+	assert.NoError(t, err)
+	assert.Equal(t, time.February, mod.Month())
+
+	mod, err = Execution{}.ParsedDateModified()
+	assert.NoError(t, err)
+	assert.True(t, mod.IsZero())
+}
+
+func TestRequestTimeHelpers(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	start := time.Date(2025, 1, 1, 2, 0, 0, 0, loc)
+	end := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	body := &JobRequestBody{}
+	body.SetStartDate(start)
+	body.SetEndDate(time.Time{})
+	assert.Equal(t, "2025-01-01T00:00:00Z", body.StartDate)
+	assert.Equal(t, "", body.EndDate)
+
+	params := ListExecutionsParams{}
+	params.SetDateRange(start, end)
+	assert.Equal(t, "2025-01-01T00:00:00Z", params.StartDate)
+	assert.Equal(t, "2025-12-31T23:59:59Z", params.EndDate)
+}
+
+type testReportPayload struct {
+	Recipients []string `json:"recipients"`
+	Subject    string   `json:"subject"`
+}
+
+type testReportPayloadV2 struct {
+	Recipients []string `json:"recipients"`
+}
+
+func (testReportPayloadV2) PayloadVersion() int { return 2 }
+
+func TestCreateTypedJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/jobs", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var jobs []JobRequestBody
+		json.NewDecoder(r.Body).Decode(&jobs)
+		assert.Len(t, jobs, 1)
+
+		payload, err := DecodeJobData[testReportPayload](Job{Data: jobs[0].Data})
+		assert.NoError(t, err)
+		assert.Equal(t, "weekly", payload.Subject)
+
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(BatchJobResponse{Success: true, Data: "request-1"})
+	}))
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+
+	result, err := CreateTypedJob(context.Background(), client, &JobRequestBody{ProjectID: 1, Timezone: "UTC"},
+		testReportPayload{Recipients: []string{"a@example.com"}, Subject: "weekly"})
+	assert.NoError(t, err)
+	assert.Equal(t, "request-1", result.Data)
+}
+
+func TestDecodeJobData_VersionMismatch(t *testing.T) {
+	data, err := EncodeJobData(testReportPayload{Subject: "weekly"})
+	assert.NoError(t, err)
+
+	_, err = DecodeJobData[testReportPayloadV2](Job{Data: data})
+	assert.ErrorIs(t, err, ErrPayloadVersionMismatch)
+
+	data, err = EncodeJobData(testReportPayloadV2{Recipients: []string{"a@example.com"}})
+	assert.NoError(t, err)
+	payload, err := DecodeJobData[testReportPayloadV2](Job{Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a@example.com"}, payload.Recipients)
+
+	_, err = DecodeJobData[testReportPayload](Job{})
+	assert.Error(t, err)
+}
+
+// Helper function to serve a paginated job list from memory
+func newJobListServer(t *testing.T, jobs []Job, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/jobs", r.URL.Path)
+		if requests != nil {
+			*requests++
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + limit
+		if end > len(jobs) {
+			end = len(jobs)
+		}
+		var page []Job
+		if offset < len(jobs) {
+			page = jobs[offset:end]
+		}
+
+		var result PaginatedJobsResponse
+		result.Success = true
+		result.Data.Total = len(jobs)
+		result.Data.Offset = offset
+		result.Data.Limit = limit
+		result.Data.Jobs = page
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}))
+}
+
+func TestJobIterator(t *testing.T) {
+	var jobs []Job
+	for i := 1; i <= 5; i++ {
+		jobs = append(jobs, Job{ID: int64(i)})
+	}
+	requests := 0
+	server := newJobListServer(t, jobs, &requests)
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+
+	all, err := client.AllJobs(context.Background(), ListJobsParams{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, all, 5)
+	assert.Equal(t, int64(5), all[4].ID)
+	assert.Equal(t, 3, requests)
+}
+
+func TestSearchJobs(t *testing.T) {
+	executor12 := int64(12)
+	executor13 := int64(13)
+	jobs := []Job{
+		{ID: 1, ExecutorID: &executor12, Spec: "0 0 * * * *", Status: "active", Timezone: "UTC", CreatedBy: "alice",
+			DateCreated: "2025-01-10T00:00:00Z", Data: `{"team":"billing","tags":["nightly"]}`},
+		{ID: 2, ExecutorID: &executor12, Spec: "0 30 9 * * 1", Status: "active", Timezone: "UTC", CreatedBy: "bob",
+			DateCreated: "2025-03-01T00:00:00Z", Data: `{"team":"search"}`},
+		{ID: 3, ExecutorID: &executor13, Spec: "0 0 * * * *", Status: "inactive", Timezone: "Europe/Berlin", CreatedBy: "alice",
+			DateCreated: "2025-01-15T00:00:00Z"},
+	}
+	requests := 0
+	server := newJobListServer(t, jobs, &requests)
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+	ctx := context.Background()
+
+	hourly := regexp.MustCompile(`^0 0 \* \* \* \*$`)
+	matched, err := client.SearchJobs(ctx, ListJobsParams{}, JobExecutorIs(12), JobSpecMatches(hourly))
+	assert.NoError(t, err)
+	assert.Len(t, matched, 1)
+	assert.Equal(t, int64(1), matched[0].ID)
+
+	cache := NewJobCache(client, ListJobsParams{}, time.Minute)
+	matched, err = cache.Search(ctx, JobCreatedBy("alice"),
+		JobCreatedBetween(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, err)
+	assert.Len(t, matched, 2)
+
+	matched, err = cache.Search(ctx, JobDataPathEquals("tags.0", "nightly"))
+	assert.NoError(t, err)
+	assert.Len(t, matched, 1)
+
+	matched, err = cache.Search(ctx, JobStatusIs("inactive"), JobTimezoneIs("Europe/Berlin"))
+	assert.NoError(t, err)
+	assert.Len(t, matched, 1)
+	assert.Equal(t, 2, requests, "cache should serve repeated searches")
+
+	data, _ := EncodeJobData(map[string]string{"team": "billing"})
+	assert.True(t, JobDataPathEquals("team", "billing")(Job{Data: data}))
+
+	// Plain data that happens to have a "payload" key is searched as is
+	plain := Job{Data: `{"payload":{"team":"billing"},"team":"search"}`}
+	assert.True(t, JobDataPathEquals("team", "search")(plain))
+	assert.True(t, JobDataPathEquals("payload.team", "billing")(plain))
+}
+
+func TestJobCacheFetchesWithoutLock(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		json.NewEncoder(w).Encode(PaginatedJobsResponse{Success: true})
+	}))
+	defer server.Close()
+
+	cache := NewJobCache(createTestAPIClient(server), ListJobsParams{}, time.Minute)
+	done := make(chan error)
+	go func() {
+		_, err := cache.Search(context.Background())
+		done <- err
+	}()
+
+	// Invalidate does not wait for the fetch in flight
+	invalidated := make(chan struct{})
+	go func() {
+		<-started
+		cache.Invalidate()
+		close(invalidated)
+	}()
+	select {
+	case <-invalidated:
+	case <-time.After(time.Second):
+		t.Fatal("Invalidate blocked on the fetch")
+	}
+	close(release)
+	assert.NoError(t, <-done)
+
+	// The fetch started before Invalidate is not cached
+	cache.mu.Lock()
+	assert.True(t, cache.fetchedAt.IsZero())
+	cache.mu.Unlock()
+}
+
+func TestWatchExecutions(t *testing.T) {
+	var mu sync.Mutex
+	executions := []Execution{
+		{ID: 1, UniqueID: "u-1", JobID: 7, State: 0, ExecutionVersion: 1, DateCreated: "2025-01-01T00:00:10Z"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/executions", r.URL.Path)
+		assert.Equal(t, "date_created", r.URL.Query().Get("orderBy"))
+		assert.Equal(t, "asc", r.URL.Query().Get("orderDirection"))
+
+		mu.Lock()
+		var result PaginatedExecutionsResponse
+		result.Success = true
+		result.Data.Total = len(executions)
+		result.Data.Executions = append([]Execution(nil), executions...)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+	store := &FileCursorStore{Path: filepath.Join(t.TempDir(), "cursor.json")}
+	params := ListExecutionsParams{StartDate: "2025-01-01T00:00:00Z"}
+	opts := []WatchOption{WithWatchCursorStore(store), WithWatchInterval(time.Millisecond, 5*time.Millisecond)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	events := client.WatchExecutions(ctx, params, opts...)
+
+	event := <-events
+	assert.Equal(t, ExecutionEventNew, event.Type)
+	assert.Equal(t, "u-1", event.Execution.UniqueID)
+
+	mu.Lock()
+	executions[0].State = 1
+	executions[0].ExecutionVersion = 2
+	executions = append(executions, Execution{ID: 2, UniqueID: "u-2", JobID: 7, ExecutionVersion: 1, DateCreated: "2025-01-01T00:00:20Z"})
+	mu.Unlock()
+
+	event = <-events
+	assert.Equal(t, ExecutionEventStateChanged, event.Type)
+	assert.Equal(t, "u-1", event.Execution.UniqueID)
+	assert.Equal(t, int64(0), event.PreviousState)
+
+	event = <-events
+	assert.Equal(t, ExecutionEventNew, event.Type)
+	assert.Equal(t, "u-2", event.Execution.UniqueID)
+	cancel()
+	for range events {
+	}
+
+	// A restarted watch resumes from the saved cursor and only reports new changes
+	mu.Lock()
+	executions = append(executions, Execution{ID: 3, UniqueID: "u-3", JobID: 7, ExecutionVersion: 1, DateCreated: "2025-01-01T00:00:30Z"})
+	mu.Unlock()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events = client.WatchExecutions(ctx, params, opts...)
+	event = <-events
+	assert.Equal(t, ExecutionEventNew, event.Type)
+	assert.Equal(t, "u-3", event.Execution.UniqueID)
+}
+
+func TestWatchExecutionsPollWindow(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		starts []string
+	)
+	executions := []Execution{
+		{ID: 1, UniqueID: "u-1", State: ExecutionStateSuccess, ExecutionVersion: 1, DateCreated: "2025-01-01T00:10:00Z"},
+		{ID: 2, UniqueID: "u-2", State: ExecutionStateSuccess, ExecutionVersion: 1, DateCreated: "not a date"},
+		{ID: 3, UniqueID: "u-3", State: ExecutionStateScheduled, ExecutionVersion: 1, DateCreated: "2025-01-01T00:05:00Z"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		starts = append(starts, r.URL.Query().Get("startDate"))
+		var result PaginatedExecutionsResponse
+		result.Success = true
+		result.Data.Total = len(executions)
+		result.Data.Executions = append([]Execution(nil), executions...)
+		mu.Unlock()
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+	lastStart := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return starts[len(starts)-1]
+	}
+
+	client := createTestAPIClient(server)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := client.WatchExecutions(ctx, ListExecutionsParams{StartDate: "2025-01-01T00:00:00Z"},
+		WithWatchInterval(time.Millisecond, time.Millisecond), WithWatchOverlap(30*time.Second))
+
+	// An execution with an unparseable DateCreated is still reported
+	var ids []string
+	for i := 0; i < 3; i++ {
+		event := <-events
+		assert.Equal(t, ExecutionEventNew, event.Type)
+		ids = append(ids, event.Execution.UniqueID)
+	}
+	assert.ElementsMatch(t, []string{"u-1", "u-2", "u-3"}, ids)
+
+	// The scheduled execution is re-read until it settles, then polls start just before the cursor
+	assert.Eventually(t, func() bool { return lastStart() == "2025-01-01T00:05:00Z" }, time.Second, time.Millisecond)
+	mu.Lock()
+	executions[2].State, executions[2].ExecutionVersion = ExecutionStateSuccess, 2
+	mu.Unlock()
+	event := <-events
+	assert.Equal(t, ExecutionEventStateChanged, event.Type)
+	assert.Equal(t, "u-3", event.Execution.UniqueID)
+	assert.Eventually(t, func() bool { return lastStart() == "2025-01-01T00:09:30Z" }, time.Second, time.Millisecond)
+
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %+v", event)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestExpectedFireTimes(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	times, err := ExpectedFireTimes("0 0 * * * *", "UTC", from, from.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, times, 4)
+	assert.True(t, from.Equal(times[0]))
+
+	times, err = ExpectedFireTimes("0 9 * * *", "America/New_York", from, from.Add(48*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, times, 2)
+	assert.Equal(t, 14, times[0].UTC().Hour())
+
+	_, err = ExpectedFireTimes("not a spec", "UTC", from, from.Add(time.Hour))
+	assert.Error(t, err)
+}
+
+func TestAuditJobSchedule(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) string { return FormatTime(from.Add(d)) }
+
+	job := Job{ID: 7, Spec: "0 0 * * * *", Timezone: "UTC", Status: "active", LastExecutionDate: at(3 * time.Hour)}
+	executions := []Execution{
+		{ID: 1, UniqueID: "a", JobID: 7, ExecutionVersion: 1, LastExecutionDatetime: at(2 * time.Second)},
+		{ID: 2, UniqueID: "a", JobID: 7, ExecutionVersion: 2, LastExecutionDatetime: at(2 * time.Second)},
+		{ID: 3, UniqueID: "b", JobID: 7, ExecutionVersion: 1, LastExecutionDatetime: at(2*time.Hour + 3*time.Minute)},
+		{ID: 4, UniqueID: "c", JobID: 7, ExecutionVersion: 1, LastExecutionDatetime: at(3 * time.Hour)},
+		{ID: 5, UniqueID: "d", JobID: 7, ExecutionVersion: 1, LastExecutionDatetime: at(3*time.Hour + time.Second)},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/executions", r.URL.Path)
+		assert.Equal(t, "7", r.URL.Query().Get("jobId"))
+
+		var result PaginatedExecutionsResponse
+		result.Success = true
+		result.Data.Total = len(executions)
+		result.Data.Executions = executions
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+
+	audit, err := client.AuditJobSchedule(context.Background(), job, ScheduleAuditOptions{
+		From: from,
+		To:   from.Add(3*time.Hour + 30*time.Minute),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, audit.Expected, 4)
+	assert.Equal(t, []time.Time{from.Add(time.Hour)}, audit.Missed)
+	assert.Len(t, audit.Late, 1)
+	assert.Equal(t, 3*time.Minute, audit.Late[0].Delay)
+	assert.Len(t, audit.Duplicates, 1)
+	assert.Len(t, audit.Duplicates[0].Executions, 2)
+	assert.Empty(t, audit.Unexpected)
+	assert.False(t, audit.Stale)
+	assert.True(t, audit.HasProblems())
+}
+
+func TestAuditJobExecutionsMatchesDelayedRuns(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) string { return FormatTime(start.Add(d)) }
+	job := Job{ID: 7, Spec: "0 0 * * * *", Timezone: "UTC", StartDate: at(0)}
+	run := func(id int64, ran, next time.Duration) Execution {
+		return Execution{ID: id, JobID: 7, ExecutionVersion: 1, LastExecutionDatetime: at(ran), NextExecutionDatetime: at(next)}
+	}
+	executions := []Execution{
+		run(1, 0, time.Hour),
+		run(2, 2*time.Hour+10*time.Minute, 2*time.Hour), // the 1:00 run, delayed past 2:00
+		run(3, 2*time.Hour, 3*time.Hour),
+	}
+
+	// The default window of 24 hours is clamped to the job's StartDate
+	audit, err := AuditJobExecutions(job, executions, ScheduleAuditOptions{To: start.Add(2*time.Hour + 30*time.Minute)})
+	assert.NoError(t, err)
+	assert.Len(t, audit.Expected, 3)
+	assert.Empty(t, audit.Missed)
+	assert.Empty(t, audit.Duplicates)
+	if assert.Len(t, audit.Late, 1) {
+		assert.Equal(t, start.Add(time.Hour), audit.Late[0].Expected)
+		assert.Equal(t, 70*time.Minute, audit.Late[0].Delay)
+	}
+
+	// Without a StartDate the job is audited over the 24 hours before To
+	job.StartDate = ""
+	audit, err = AuditJobExecutions(job, nil, ScheduleAuditOptions{To: start})
+	assert.NoError(t, err)
+	assert.Len(t, audit.Expected, 25)
+
+	// A window too large to expand fails before executions are fetched
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	defer server.Close()
+	job.Spec = "* * * * * *"
+	_, err = createTestAPIClient(server).AuditJobSchedule(context.Background(), job, ScheduleAuditOptions{From: start.AddDate(0, 0, -7), To: start})
+	assert.ErrorIs(t, err, ErrTooManyFireTimes)
+	assert.ErrorContains(t, err, "audit a shorter window")
+}
+
+func TestJobAndProjectStats(t *testing.T) {
+	base := time.Now().UTC().Add(-time.Hour).Truncate(time.Minute)
+	run := func(id int64, jobID int64, state int64, minute int, delay time.Duration) Execution {
+		ran := base.Add(time.Duration(minute) * time.Minute)
+		return Execution{
+			ID:                    id,
+			UniqueID:              fmt.Sprintf("run-%d", id),
+			JobID:                 jobID,
+			State:                 state,
+			ExecutionVersion:      1,
+			LastExecutionDatetime: FormatTime(ran.Add(delay)),
+			NextExecutionDatetime: FormatTime(ran.Add(time.Minute)),
+		}
+	}
+	executions := []Execution{
+		run(1, 7, ExecutionStateSuccess, 0, 0),
+		run(2, 7, ExecutionStateFailed, 1, 0),
+		run(3, 7, ExecutionStateFailed, 2, 0),
+		run(4, 7, ExecutionStateSuccess, 3, 2*time.Second),
+		run(5, 7, ExecutionStateFailed, 4, 0),
+		run(6, 8, ExecutionStateSuccess, 0, 0),
+		run(7, 8, ExecutionStateSuccess, 1, 0),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/executions", r.URL.Path)
+		var matched []Execution
+		for _, execution := range executions {
+			if jobID := r.URL.Query().Get("jobId"); jobID == "" || jobID == strconv.FormatInt(execution.JobID, 10) {
+				matched = append(matched, execution)
+			}
+		}
+		var result PaginatedExecutionsResponse
+		result.Success = true
+		result.Data.Total = len(matched)
+		result.Data.Executions = matched
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+	ctx := context.Background()
+
+	stats, err := client.JobStats(ctx, 7, 2*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, stats.Total)
+	assert.Equal(t, 2, stats.Succeeded)
+	assert.Equal(t, 3, stats.Failed)
+	assert.Equal(t, 2, stats.LongestFailureStreak)
+	assert.Equal(t, 1, stats.CurrentFailureStreak)
+	assert.Equal(t, 3, stats.StateFlips)
+	assert.Equal(t, int64(5), stats.LastFailure.ID)
+	assert.InDelta(t, 0.4, stats.SuccessRatio, 0.0001)
+	assert.Equal(t, 2*time.Second, stats.MaxDrift)
+	assert.Equal(t, 500*time.Millisecond, stats.AverageDrift)
+
+	stats, err = client.ProjectStats(ctx, 1, 2*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, stats.Total)
+	assert.Len(t, stats.Jobs, 2)
+	assert.Equal(t, int64(7), stats.Jobs[0].JobID)
+	assert.Equal(t, 1.0, stats.Jobs[1].SuccessRatio)
+}
+
+func TestExportExecutions(t *testing.T) {
+	var executions []Execution
+	for i := 1; i <= 3; i++ {
+		executions = append(executions, Execution{
+			ID:          int64(i),
+			UniqueID:    fmt.Sprintf("run-%d", i),
+			JobID:       7,
+			State:       ExecutionStateSuccess,
+			DateCreated: "2025-01-01T12:00:00Z",
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + limit
+		if end > len(executions) {
+			end = len(executions)
+		}
+		var result PaginatedExecutionsResponse
+		result.Success = true
+		result.Data.Total = len(executions)
+		result.Data.Executions = executions[offset:end]
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	count, err := client.ExportExecutions(ctx, ListExecutionsParams{Limit: 2}, &buf, ExportCSV)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, strings.Join(ExportFields(), ","), lines[0])
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+
+	buf.Reset()
+	count, err = client.ExportExecutions(ctx, ListExecutionsParams{Limit: 2}, &buf, ExportNDJSON,
+		WithExportFields("uniqueId", "dateCreated", "dateModified"), WithExportLocation(tokyo))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, `{"uniqueId":"run-1","dateCreated":"2025-01-01T21:00:00+09:00","dateModified":null}`, lines[0])
+
+	_, err = client.ExportExecutions(ctx, ListExecutionsParams{}, &buf, ExportCSV, WithExportFields("nope"))
+	assert.Error(t, err)
+}
+
+func TestRetentionManager(t *testing.T) {
+	var cleaned []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/executions":
+			assert.NotEmpty(t, r.URL.Query().Get("endDate"))
+			var result PaginatedExecutionsResponse
+			result.Success = true
+			result.Data.Total = 42
+			json.NewEncoder(w).Encode(result)
+		case "/api/v1/executions/cleanup-old-logs":
+			var body CleanupOldLogsRequestBody
+			json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, body.AccountID, r.Header.Get("X-Account-ID"))
+			cleaned = append(cleaned, body.AccountID)
+			var result CleanupOldLogsResponse
+			result.Success = true
+			result.Data.Message = "cleaned"
+			json.NewEncoder(w).Encode(result)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+	config := RetentionConfig{
+		MinRetentionMonths: 3,
+		Policies: []RetentionPolicy{
+			{AccountID: "1", RetentionMonths: 6},
+			{AccountID: "2", RetentionMonths: 12},
+		},
+	}
+
+	var log bytes.Buffer
+	manager, err := NewRetentionManager(client, config, &JSONLinesRecorder{W: &log})
+	assert.NoError(t, err)
+
+	results, err := manager.Apply(context.Background(), true)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, 42, results[0].AffectedExecutions)
+	assert.False(t, results[0].Cleaned)
+	assert.Empty(t, cleaned)
+
+	results, err = manager.Apply(context.Background(), false)
+	assert.NoError(t, err)
+	assert.True(t, results[1].Cleaned)
+	assert.Equal(t, []string{"1", "2"}, cleaned)
+	assert.Equal(t, 4, strings.Count(log.String(), "\n"))
+
+	config.Policies = append(config.Policies, RetentionPolicy{AccountID: "3", RetentionMonths: 1})
+	_, err = NewRetentionManager(client, config, nil)
+	assert.ErrorIs(t, err, ErrRetentionBelowFloor)
+}
+
+type testInvoicePayload struct {
+	Amount int `json:"amount"`
+}
+
+func (testInvoicePayload) PayloadType() string { return "invoice" }
+
+type testReceiptPayload struct{}
+
+func (testReceiptPayload) PayloadType() string { return "receipt" }
+
+func TestJobDataType(t *testing.T) {
+	data, err := EncodeJobData(testInvoicePayload{Amount: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, "invoice", JobDataType(Job{Data: data}))
+	assert.Equal(t, "", JobDataType(Job{Data: "plain text"}))
+
+	_, err = DecodeJobData[testReceiptPayload](Job{Data: data})
+	assert.ErrorIs(t, err, ErrPayloadTypeMismatch)
+
+	// A named type does not decode a payload written without a type
+	untyped, err := EncodeJobData(map[string]int{"amount": 10})
+	assert.NoError(t, err)
+	_, err = DecodeJobData[testInvoicePayload](Job{Data: untyped})
+	assert.ErrorIs(t, err, ErrPayloadTypeMismatch)
+	_, err = DecodeJobData[map[string]int](Job{Data: data})
+	assert.NoError(t, err)
+
+	body := []byte(`{"id":1}`)
+	signature := SignWebhookPayload("secret", 1700000000, "nonce", body)
+	assert.True(t, VerifyWebhookSignature("secret", signature, 1700000000, "nonce", body))
+	assert.False(t, VerifyWebhookSignature("secret", signature, 1700000001, "nonce", body))
+}
+
+func TestTypedExecutors(t *testing.T) {
+	webhook, err := NewWebhookExecutor("hook", "https://example.com/hook", "post", "secret")
+	assert.NoError(t, err)
+	body := webhook.CreateRequest("user-1")
+	assert.Equal(t, ExecutorTypeWebhook, body.Type)
+	assert.Equal(t, "POST", body.WebhookMethod)
+
+	_, err = NewWebhookExecutor("hook", "ftp://example.com", "PATCH", "")
+	assert.ErrorContains(t, err, "scheme")
+	assert.ErrorContains(t, err, "method")
+	assert.ErrorContains(t, err, "secret")
+
+	credentials := CloudCredentials{APIKey: "key", APISecret: "secret"}
+	_, err = NewCloudFunctionExecutor("fn", "aws", "us-west-1", "arn:aws:lambda:us-west-1:123456789012:function:report", credentials)
+	assert.NoError(t, err)
+	_, err = NewCloudFunctionExecutor("fn", "aws", "us-east-1", "arn:aws:lambda:us-west-1:123456789012:function:report", credentials)
+	assert.ErrorContains(t, err, "does not match region")
+	_, err = NewCloudFunctionExecutor("fn", "gcp", "us-west-1", "https://example.com/fn", credentials)
+	assert.ErrorContains(t, err, "not a valid gcp region")
+
+	container, err := NewContainerExecutor("job", "azure", "westeurope", "registry.example.com/jobs:1.0", credentials)
+	assert.NoError(t, err)
+	assert.Equal(t, "registry.example.com/jobs:1.0", container.CreateRequest("user-1").CloudResourceURL)
+
+	decoded, err := DecodeExecutor(Executor{Type: "container", Name: "job", CloudResourceURL: "registry.example.com/jobs:1.0"})
+	assert.NoError(t, err)
+	assert.Equal(t, "registry.example.com/jobs:1.0", decoded.(*ContainerExecutor).ImageURL)
+
+	_, err = DecodeExecutor(Executor{Type: "carrier_pigeon"})
+	assert.Error(t, err)
+}
+
+func TestTestExecutorReportsProblems(t *testing.T) {
+	var signature, dryRun string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(WebhookSignatureHeader)
+		dryRun = r.Header.Get(WebhookDryRunHeader)
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+	executor := Executor{ID: 3, Type: ExecutorTypeWebhook, WebhookURL: server.URL, WebhookMethod: "put", WebhookSecret: "secret"}
+	report, err := client.TestExecutor(context.Background(), executor, WithExecutorTestMaxLatency(time.Millisecond))
+	assert.NoError(t, err)
+	assert.False(t, report.Success)
+	assert.Equal(t, "PUT", report.Method)
+	assert.Equal(t, http.StatusServiceUnavailable, report.StatusCode)
+	assert.Nil(t, report.TLS)
+	assert.Len(t, report.Problems, 3)
+	assert.True(t, strings.HasPrefix(signature, "sha256="))
+	assert.Equal(t, "true", dryRun)
+
+	_, err = client.TestExecutor(context.Background(), Executor{Type: ExecutorTypeContainer})
+	assert.Error(t, err)
+}
+
+func TestMigrateExecutor(t *testing.T) {
+	executorID := func(id int64) *int64 { return &id }
+	jobs := []Job{
+		{ID: 1, AccountID: 123, ProjectID: 5, ExecutorID: executorID(10), Spec: "0 * * * *", Data: `{"n":1}`, Status: "active"},
+		{ID: 2, AccountID: 123, ProjectID: 5, ExecutorID: executorID(20), Spec: "0 * * * *"},
+		{ID: 3, AccountID: 123, ProjectID: 5, ExecutorID: executorID(10), Spec: "*/5 * * * *", RetryMax: 3},
+		{ID: 4, AccountID: 123, ProjectID: 5, ExecutorID: executorID(10), Spec: "@daily", Timezone: "UTC"},
+	}
+	originals := append([]Job(nil), jobs...)
+	var mu sync.Mutex
+	deleted := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/jobs":
+			var result PaginatedJobsResponse
+			result.Success = true
+			result.Data.Total = len(jobs)
+			result.Data.Jobs = jobs
+			json.NewEncoder(w).Encode(result)
+		case strings.HasPrefix(r.URL.Path, "/api/v1/jobs/"):
+			id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/api/v1/jobs/"), 10, 64)
+			assert.Equal(t, "123", r.Header.Get("X-Account-ID"))
+			for i := range jobs {
+				if jobs[i].ID != id {
+					continue
+				}
+				if r.Method == "PUT" {
+					// A PUT replaces the job, so fields missing from the body are cleared
+					var body JobUpdateRequestBody
+					json.NewDecoder(r.Body).Decode(&body)
+					jobs[i] = Job{ID: id, AccountID: jobs[i].AccountID, ProjectID: body.ProjectID, ExecutorID: body.ExecutorID,
+						Data: body.Data, Spec: body.Spec, Timezone: body.Timezone, RetryMax: body.RetryMax, Status: body.Status}
+				}
+				json.NewEncoder(w).Encode(JobResponse{Success: true, Data: jobs[i]})
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "DELETE":
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+	rollbackPath := filepath.Join(t.TempDir(), "rollback.json")
+
+	result, err := client.MigrateExecutor(context.Background(), 10, 30, MigrateExecutorOptions{
+		Concurrency:  2,
+		RateLimit:    1000,
+		RollbackPath: rollbackPath,
+		ModifiedBy:   "user-1",
+		DeleteOld:    true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Matched)
+	assert.Equal(t, 3, result.Updated)
+	assert.Empty(t, result.Failed)
+	assert.True(t, result.ExecutorDeleted)
+	assert.Equal(t, "/api/v1/executors/10", deleted)
+	assert.Equal(t, int64(30), *jobs[0].ExecutorID)
+	assert.Equal(t, int64(20), *jobs[1].ExecutorID)
+	assert.Equal(t, originals[2].Spec, jobs[2].Spec)
+	assert.Equal(t, originals[2].RetryMax, jobs[2].RetryMax)
+
+	// The rollback file is written through a temporary file that does not linger
+	entries, err := os.ReadDir(filepath.Dir(rollbackPath))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	result, err = client.RollbackExecutorMigration(context.Background(), rollbackPath, MigrateExecutorOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Updated)
+	for _, job := range []Job{jobs[0], jobs[2], jobs[3]} {
+		assert.Equal(t, int64(10), *job.ExecutorID)
+	}
+	assert.Equal(t, originals, jobs)
+}
+
+func TestMigrateExecutorRerunKeepsRollback(t *testing.T) {
+	executorID := func(id int64) *int64 { return &id }
+	jobs := map[int64]*Job{
+		1: {ID: 1, AccountID: 123, ExecutorID: executorID(10)},
+		2: {ID: 2, AccountID: 123, ExecutorID: executorID(10)},
+	}
+	var mu sync.Mutex
+	failing := int64(2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/api/v1/jobs" {
+			var result PaginatedJobsResponse
+			result.Success = true
+			for _, id := range []int64{1, 2} {
+				result.Data.Jobs = append(result.Data.Jobs, *jobs[id])
+			}
+			result.Data.Total = len(result.Data.Jobs)
+			json.NewEncoder(w).Encode(result)
+			return
+		}
+		id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/api/v1/jobs/"), 10, 64)
+		if r.Method == "PUT" {
+			if id == failing {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			var body JobUpdateRequestBody
+			json.NewDecoder(r.Body).Decode(&body)
+			jobs[id].ExecutorID = body.ExecutorID
+		}
+		json.NewEncoder(w).Encode(JobResponse{Success: true, Data: *jobs[id]})
+	}))
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+	rollbackPath := filepath.Join(t.TempDir(), "rollback.json")
+	result, err := client.MigrateExecutor(context.Background(), 10, 30, MigrateExecutorOptions{RollbackPath: rollbackPath})
+	assert.NoError(t, err)
+	assert.Len(t, result.Failed, 1)
+
+	// The rerun only matches job 2, but the rollback file still restores job 1
+	mu.Lock()
+	failing = 0
+	mu.Unlock()
+	result, err = client.MigrateExecutor(context.Background(), 10, 30, MigrateExecutorOptions{RollbackPath: rollbackPath})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Matched)
+	_, err = client.RollbackExecutorMigration(context.Background(), rollbackPath, MigrateExecutorOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), *jobs[1].ExecutorID)
+	assert.Equal(t, int64(10), *jobs[2].ExecutorID)
+
+	// A rollback file of another migration is not overwritten
+	_, err = client.MigrateExecutor(context.Background(), 10, 40, MigrateExecutorOptions{RollbackPath: rollbackPath})
+	assert.ErrorContains(t, err, "records a migration from executor 10 to 30")
+}
+
+func TestSecretRedaction(t *testing.T) {
+	credential := Credential{ID: 9007199254740993, APIKey: "key", APISecret: "cred-secret"}
+	executor := Executor{Name: "hook", WebhookSecret: "hook-secret", CloudAPISecret: "cloud-secret"}
+	client, err := NewClient("http://localhost", "v1", WithAPIKey("key", "client-secret"), WithBasicAuth("peer", "peer-password"))
+	assert.NoError(t, err)
+
+	for _, out := range []string{
+		fmt.Sprintf("%v %+v %s", credential, credential, credential),
+		fmt.Sprintf("%+v %#v", executor, executor),
+		fmt.Sprintf("%+v", client),
+		fmt.Sprint([]Credential{credential}),
+	} {
+		assert.NotContains(t, out, "secret")
+		assert.NotContains(t, out, "password")
+		assert.Contains(t, out, redactedValue)
+	}
+	assert.Contains(t, credential.Reveal(), "cred-secret")
+	assert.Contains(t, client.Reveal(), "peer-password")
+
+	secrets := []string{"cred-secret", "hook-secret", "cloud-secret", "client-secret", "peer-password"}
+	for name, newHandler := range map[string]func(io.Writer) slog.Handler{
+		"json": func(w io.Writer) slog.Handler { return slog.NewJSONHandler(w, nil) },
+		"text": func(w io.Writer) slog.Handler { return slog.NewTextHandler(w, nil) },
+	} {
+		var buf bytes.Buffer
+		logger := slog.New(newHandler(&buf))
+		logger.Info("loaded", "credential", credential, "executor", executor, "client", client)
+		for _, secret := range secrets {
+			assert.NotContains(t, buf.String(), secret, name)
+		}
+		assert.Contains(t, buf.String(), redactedValue, name)
+		assert.Contains(t, buf.String(), "hook", name)
+	}
+
+	data, err := MarshalRedacted(PaginatedCredentialsResponse{Data: struct {
+		Total       int          `json:"total"`
+		Offset      int          `json:"offset"`
+		Limit       int          `json:"limit"`
+		Credentials []Credential `json:"credentials"`
+	}{Credentials: []Credential{credential}}})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "cred-secret")
+	assert.Contains(t, string(data), `"apiSecret":"[REDACTED]"`)
+	assert.Contains(t, string(data), "9007199254740993")
+
+	data, err = json.Marshal(credential)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "cred-secret")
+}
+
+func TestRotateCredential(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	verifyStatus := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+r.Header.Get("X-API-Key"))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/credentials":
+			json.NewEncoder(w).Encode(CredentialResponse{Success: true, Data: Credential{ID: 2, AccountID: 123, APIKey: "new-key", APISecret: "new-secret"}})
+		case r.Method == "GET" && r.URL.Path == "/api/v1/credentials/2":
+			w.WriteHeader(verifyStatus)
+			json.NewEncoder(w).Encode(CredentialResponse{Success: true, Data: Credential{ID: 2}})
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+	worker := createTestAPIClient(server)
+	var audit bytes.Buffer
+	result, err := client.RotateCredential(context.Background(), 1, RotateCredentialOptions{
+		Actor:       "ops",
+		Clients:     []*Client{client, worker},
+		GracePeriod: time.Millisecond,
+		DeleteOld:   true,
+		Recorder:    &JSONLinesRecorder{W: &audit},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.NewCredential.ID)
+	assert.Equal(t, []string{
+		"POST /api/v1/credentials mock-api-key",
+		"GET /api/v1/credentials/2 new-key",
+		"POST /api/v1/credentials/1/archive new-key",
+		"DELETE /api/v1/credentials/1 new-key",
+	}, calls)
+	key, secret := worker.apiKey()
+	assert.Equal(t, "new-key", key)
+	assert.Equal(t, "new-secret", secret)
+
+	var steps []string
+	for _, line := range strings.Split(strings.TrimSpace(audit.String()), "\n") {
+		var entry RotationAuditEntry
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		steps = append(steps, entry.Step)
+	}
+	assert.Equal(t, []string{RotationStepCreate, RotationStepVerify, RotationStepSwap, RotationStepGrace, RotationStepArchive, RotationStepDelete}, steps)
+
+	// A credential that fails verification is deleted and clients keep the old key
+	calls = nil
+	verifyStatus = http.StatusUnauthorized
+	other := createTestAPIClient(server)
+	result, err = other.RotateCredential(context.Background(), 1, RotateCredentialOptions{Clients: []*Client{other}})
+	assert.Error(t, err)
+	assert.Equal(t, "DELETE /api/v1/credentials/2 mock-api-key", calls[len(calls)-1])
+	assert.Equal(t, RotationStepRollback, result.Audit[len(result.Audit)-1].Step)
+	key, _ = other.apiKey()
+	assert.Equal(t, "mock-api-key", key)
+}
+
+func TestCredentialsProviders(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := StaticCredentialsProvider{}.Credentials(ctx)
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvAPISecret, "env-secret")
+	creds, err := EnvCredentialsProvider{}.Credentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "env-key", creds.APIKey)
+
+	chain := ChainCredentialsProvider{StaticCredentialsProvider{}, EnvCredentialsProvider{}}
+	creds, err = chain.Credentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "env-secret", creds.APISecret)
+	assert.NotContains(t, fmt.Sprintf("%+v", creds), "env-secret")
+
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	writeCredentials := func(key string) {
+		data, _ := json.Marshal(Credentials{APIKey: key, APISecret: key + "-secret"})
+		assert.NoError(t, os.WriteFile(path, data, 0o600))
+	}
+	writeCredentials("file-key-1")
+	provider, err := NewFileCredentialsProvider(path, 0)
+	assert.NoError(t, err)
+
+	var mu sync.Mutex
+	seen := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Header.Get("X-API-Key")]++
+		mu.Unlock()
+		json.NewEncoder(w).Encode(CredentialResponse{Success: true})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "v1", WithAPIKey("ignored", "ignored"), WithCredentialsProvider(provider))
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				_, err := client.GetCredential("1")
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A torn write keeps the last good credentials, a complete one is picked up
+	assert.NoError(t, os.WriteFile(path, []byte(`{"apiKey":`), 0o600))
+	_, err = client.GetCredential("1")
+	assert.NoError(t, err)
+	writeCredentials("file-key-rotated")
+	_, err = client.GetCredential("1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 41, seen["file-key-1"])
+	assert.Equal(t, 1, seen["file-key-rotated"])
+	assert.Zero(t, seen["ignored"])
+
+	failing, err := NewClient(server.URL, "v1", WithCredentialsProvider(StaticCredentialsProvider{}))
+	assert.NoError(t, err)
+	_, err = failing.GetCredential("1")
+	assert.ErrorIs(t, err, ErrNoCredentials)
+}
+
+// contextCredentialsProvider records the contexts it is asked for credentials with
+type contextCredentialsProvider struct {
+	ctxs []context.Context
+}
+
+func (p *contextCredentialsProvider) Credentials(ctx context.Context) (Credentials, error) {
+	p.ctxs = append(p.ctxs, ctx)
+	if err := ctx.Err(); err != nil {
+		return Credentials{}, err
+	}
+	return Credentials{APIKey: "key", APISecret: "secret"}, nil
+}
+
+func TestCredentialsProviderContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(PaginatedJobsResponse{Success: true})
+	}))
+	defer server.Close()
+
+	provider := &contextCredentialsProvider{}
+	client, err := NewClient(server.URL, "v1", WithAccountID("123"), WithCredentialsProvider(provider))
+	assert.NoError(t, err)
+
+	type callerKey struct{}
+	ctx := context.WithValue(context.Background(), callerKey{}, "caller")
+	_, err = client.AllJobs(ctx, ListJobsParams{})
+	assert.NoError(t, err)
+	if assert.Len(t, provider.ctxs, 1) {
+		assert.Equal(t, "caller", provider.ctxs[0].Value(callerKey{}))
+	}
+
+	// WithTimeout bounds the provider as well
+	_, err = client.ListJobs(ListJobsParams{}, WithTimeout(time.Minute))
+	assert.NoError(t, err)
+	if assert.Len(t, provider.ctxs, 2) {
+		_, ok := provider.ctxs[1].Deadline()
+		assert.True(t, ok)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = client.AllJobs(cancelled, ListJobsParams{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCredentialsRedaction(t *testing.T) {
+	creds := Credentials{APIKey: "key", APISecret: "api-secret-value", Username: "peer", Password: "peer-password-value"}
+
+	data, err := MarshalRedacted(creds)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"apiKey":"key","apiSecret":"[REDACTED]","username":"peer","password":"[REDACTED]"}`, string(data))
+
+	// Plain JSON keeps the secrets so credentials files can be written, but a file written from
+	// redacted output is refused
+	data, err = json.Marshal(creds)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "api-secret-value")
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"apiKey":"key","apiSecret":"[REDACTED]"}`), 0o600))
+	_, err = NewFileCredentialsProvider(path, 0)
+	assert.ErrorContains(t, err, "redacted secret")
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("loaded", "creds", creds)
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("loaded", "creds", creds)
+	assert.NotContains(t, buf.String(), "api-secret-value")
+	assert.NotContains(t, buf.String(), "peer-password-value")
+	assert.Contains(t, buf.String(), "peer")
+}
+
+func TestAuditCredentials(t *testing.T) {
+	deletedAt := "2024-05-01T00:00:00Z"
+	credentials := []Credential{
+		{ID: 1, AccountID: 123, APIKey: "k1", DateCreated: "2023-01-01T00:00:00Z", CreatedBy: "alice"},
+		{ID: 2, AccountID: 123, APIKey: "k2", DateCreated: "2024-05-01T00:00:00Z", CreatedBy: "Bob"},
+		{ID: 3, AccountID: 123, APIKey: "mock-api-key", DateCreated: "2024-05-20T00:00:00Z", CreatedBy: "carol"},
+		{ID: 4, AccountID: 123, APIKey: "k4", DateCreated: "2024-01-01T00:00:00Z", Archived: true},
+		{ID: 5, AccountID: 123, APIKey: "k5", DateCreated: "2024-01-01T00:00:00Z", Archived: true, DateDeleted: &deletedAt},
+		{ID: 6, AccountID: 456, APIKey: "k6", DateCreated: "2024-05-01T00:00:00Z", CreatedBy: "carol"},
+	}
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			end := min(offset+limit, len(credentials))
+			var result PaginatedCredentialsResponse
+			result.Data.Total = len(credentials)
+			result.Data.Credentials = credentials[min(offset, end):end]
+			json.NewEncoder(w).Encode(result)
+			return
+		}
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+r.Header.Get("X-Account-ID"))
+	}))
+	defer server.Close()
+
+	client := createTestAPIClient(server)
+	report, err := client.AuditCredentials(context.Background(), CredentialAuditOptions{
+		Params:              ListCredentialsParams{Limit: 4},
+		MaxAge:              365 * 24 * time.Hour,
+		DepartedUsers:       []string{"bob"},
+		MaxActivePerAccount: 2,
+		Now:                 time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, report.Credentials)
+	assert.Equal(t, []AccountCredentialCount{{AccountID: 123, Active: 3}}, report.CrowdedAccounts)
+
+	issues := map[int64][]string{}
+	for _, finding := range report.Findings {
+		issues[finding.Credential.ID] = finding.Issues
+	}
+	assert.Equal(t, map[int64][]string{
+		1: {CredentialIssueTooOld, CredentialIssueTooManyActive},
+		2: {CredentialIssueDepartedCreator},
+		4: {CredentialIssueArchivedNotDeleted},
+	}, issues)
+
+	plan := report.RemediationPlan()
+	plan = append(plan, CredentialRemediation{CredentialID: 3, AccountID: 123, APIKey: "mock-api-key", Action: RemediationArchive})
+	results, err := client.ApplyCredentialRemediation(context.Background(), plan, "auditor")
+	assert.NoError(t, err)
+	assert.Len(t, results, 4)
+	assert.Equal(t, "credential is used by this client", results[3].Skipped)
+	assert.Equal(t, []string{
+		"POST /api/v1/credentials/1/archive 123",
+		"POST /api/v1/credentials/2/archive 123",
+		"DELETE /api/v1/credentials/4 123",
+	}, calls)
+
+	// Without its own credentials the client cannot tell which key to spare
+	client.credentials = StaticCredentialsProvider{}
+	results, err = client.ApplyCredentialRemediation(context.Background(), plan, "auditor")
+	assert.ErrorIs(t, err, ErrNoCredentials)
+	assert.Empty(t, results)
+	assert.Len(t, calls, 3)
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv(EnvURL, "")
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvAPISecret, "")
+	_, err := NewClientFromEnv()
+	assert.ErrorIs(t, err, ErrMissingConfigValue)
+	assert.ErrorContains(t, err, EnvURL)
+	assert.ErrorContains(t, err, EnvAPISecret)
+
+	t.Setenv(EnvURL, "https://scheduler0.example.com")
+	t.Setenv(EnvAPISecret, "env-secret")
+	t.Setenv(EnvAccountID, "42")
+	client, err := NewClientFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, "env-key", client.APIKey)
+	assert.Equal(t, "42", client.AccountID)
+	assert.Equal(t, "v1", client.Version)
+}
+
+func TestNewClientFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "config")
+	assert.NoError(t, os.WriteFile(yamlPath, []byte(`
+default_profile: staging
+profiles:
+  staging:
+    url: https://staging.example.com
+    api_key: staging-key
+    api_secret: staging-secret
+    account_id: "7"
+  production:
+    url: https://scheduler0.example.com
+    version: v2
+    username: peer
+    password: peer-password
+  broken:
+    api_key: only-key
+`), 0o600))
+
+	t.Setenv(EnvProfile, "")
+	client, err := NewClientFromConfig(yamlPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "staging-key", client.APIKey)
+	assert.Equal(t, "7", client.AccountID)
+
+	t.Setenv(EnvProfile, "production")
+	client, err = NewClientFromConfig(yamlPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "peer", client.Username)
+	assert.Equal(t, "v2", client.Version)
+
+	_, err = NewClientFromConfigProfile(yamlPath, "broken")
+	assert.ErrorIs(t, err, ErrMissingConfigValue)
+	assert.ErrorContains(t, err, "url")
+	assert.ErrorContains(t, err, "api_secret")
+
+	_, err = NewClientFromConfigProfile(yamlPath, "qa")
+	assert.ErrorContains(t, err, "available profiles: broken, production, staging")
+
+	jsonPath := filepath.Join(dir, "config.json")
+	assert.NoError(t, os.WriteFile(jsonPath, []byte(`{"profiles": {"default": {"url": "https://scheduler0.example.com", "api_key": "k", "api_secret": "s"}}}`), 0o600))
+	t.Setenv(EnvProfile, "")
+	client, err = NewClientFromConfig(jsonPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "k", client.APIKey)
+
+	profile, err := (&ClientConfig{Profiles: map[string]ConfigProfile{"default": {APISecret: "s3cr3t"}}}).Profile("")
+	assert.NoError(t, err)
+	assert.NotContains(t, fmt.Sprintf("%+v", profile), "s3cr3t")
+}
+
+func TestTenantClient(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Header.Get("X-Account-ID")+" "+r.Header.Get("X-API-Key"))
+		mu.Unlock()
+		json.NewEncoder(w).Encode(CredentialResponse{Success: true})
+	}))
+	defer server.Close()
+
+	base := createTestAPIClient(server)
+	pool := NewTenantPool(base, func(accountID string) []TenantOption {
+		if accountID == "2" {
+			return []TenantOption{WithTenantAPIKey("tenant-2-key", "tenant-2-secret"), WithTenantRateLimit(20, 1)}
+		}
+		return nil
+	})
+	tenant1, err := pool.ForAccount("1")
+	assert.NoError(t, err)
+	again, _ := pool.ForAccount("1")
+	assert.Same(t, tenant1, again)
+
+	_, err = tenant1.GetCredential("5")
+	assert.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		tenant2, err := pool.ForAccount("2")
+		assert.NoError(t, err)
+		_, err = tenant2.GetCredential("5")
+		assert.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	assert.Equal(t, []string{"1 mock-api-key", "2 tenant-2-key", "2 tenant-2-key", "2 tenant-2-key"}, requests)
+
+	tenant, err := base.ForAccount("1")
+	assert.NoError(t, err)
+	_, err = tenant.UpdateJob("9", &JobUpdateRequestBody{ModifiedBy: "me"}, WithAccount("2"))
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+	_, err = tenant.BatchCreateJobs([]JobRequestBody{{AccountID: 1}, {AccountID: 2}})
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+	assert.Len(t, requests, 4)
+
+	_, err = tenant.UpdateJob("9", &JobUpdateRequestBody{AccountID: 1, ModifiedBy: "me"}, WithAccount("1"))
+	assert.NoError(t, err)
+
+	// Tenants need a real account and cannot be re-pinned through the embedded client
+	for _, accountID := range []string{"", "abc", "0", "-3"} {
+		_, err = base.ForAccount(accountID)
+		assert.Error(t, err, accountID)
+	}
+	_, err = pool.ForAccount("")
+	assert.Error(t, err)
+	_, err = tenant.ForAccount("2")
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+	_, err = tenant.Client.ForAccount("2")
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+	_, err = tenant.ForAccount("1", WithTenantRateLimit(5, 1))
+	assert.NoError(t, err)
+
+	// Reassigning the exported field neither moves the tenant nor lets it re-pin
+	tenant.AccountID = "2"
+	_, err = tenant.GetCredential("5")
+	assert.NoError(t, err)
+	assert.Equal(t, "1 mock-api-key", requests[len(requests)-1])
+	_, err = tenant.ForAccount("2")
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+	_, err = tenant.GetCredential("5", WithAccount("2"))
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+}
+
+func TestStrictAccountScoping(t *testing.T) {
+	var accounts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accounts = append(accounts, r.Header.Get("X-Account-ID"))
+		json.NewEncoder(w).Encode(BatchJobResponse{Success: true})
+	}))
+	defer server.Close()
+
+	mixed := []JobRequestBody{{AccountID: 1, Spec: "a"}, {AccountID: 2, Spec: "b"}, {AccountID: 1, Spec: "c"}, {Spec: "d"}}
+
+	// Without strict scoping the first job's account silently wins
+	client := createTestAPIClient(server)
+	_, err := client.BatchCreateJobs(mixed)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, accounts)
+
+	strict := createTestAPIClient(server)
+	WithStrictAccountScoping()(strict)
+	_, err = strict.BatchCreateJobs(mixed)
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+	_, err = strict.UpdateJob("1", &JobUpdateRequestBody{AccountID: 1}, WithAccount("2"))
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+	_, err = strict.BatchCreateJobs(mixed[:1], WithAccount("1"))
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 2)
+
+	accounts = nil
+	results, err := strict.BatchCreateJobsPerAccount(context.Background(), mixed)
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, []string{"1", "2", "123"}, accounts)
+
+	// A WithAccount that contradicts a group is rejected before anything is sent
+	accounts = nil
+	results, err = strict.BatchCreateJobsPerAccount(context.Background(), mixed, WithAccount("1"))
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+	assert.Empty(t, results)
+	assert.Empty(t, accounts)
+
+	results, err = strict.BatchCreateJobsPerAccount(context.Background(), mixed[:1], WithAccount("1"))
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, []string{"1"}, accounts)
+}
+
+func TestRequestOptions(t *testing.T) {
+	var last *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = r
+		if r.URL.Path == "/api/v1/jobs/slow" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer server.Close()
+	client := createTestAPIClient(server)
+
+	_, err := client.GetJob("1",
+		WithAccount("7"),
+		WithHeader("X-Trace", "abc"),
+		WithHeader("X-API-Key", "spoofed"),
+		WithHeader("Content-Type", "text/plain"),
+		WithIdempotencyKey("key-1"),
+		WithRequestID("req-1"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", last.Header.Get("X-Account-ID"))
+	assert.Equal(t, "abc", last.Header.Get("X-Trace"))
+	assert.Equal(t, "mock-api-key", last.Header.Get("X-API-Key"))
+	assert.Equal(t, "application/json", last.Header.Get("Content-Type"))
+	assert.Equal(t, "key-1", last.Header.Get(IdempotencyKeyHeader))
+	assert.Equal(t, "req-1", last.Header.Get(RequestIDHeader))
+
+	// Params and options must agree on the account, and empty values are ignored
+	_, err = client.ListProjects(ListProjectsParams{AccountID: 5}, WithAccount("9"))
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+	_, err = client.GetExecutionTotals(5, WithAccount("9"))
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+	_, err = client.ListProjects(ListProjectsParams{AccountID: 9}, WithAccount("9"), WithRequestID(""))
+	assert.NoError(t, err)
+	assert.Equal(t, "9", last.Header.Get("X-Account-ID"))
+	assert.Empty(t, last.Header.Get(RequestIDHeader))
+	_, err = client.ListJobs(ListJobsParams{}, WithAccount("8"))
+	assert.NoError(t, err)
+	assert.Equal(t, "8", last.Header.Get("X-Account-ID"))
+
+	_, err = client.Healthcheck(WithRequestID("health"), WithHeader("Content-Type", "text/plain"))
+	assert.NoError(t, err)
+	assert.Equal(t, "health", last.Header.Get(RequestIDHeader))
+	assert.Equal(t, "application/json", last.Header.Get("Content-Type"))
+
+	// Auth headers cannot be injected whatever the client authenticates with
+	spoofed := []RequestOption{
+		WithHeader("authorization", "Bearer spoofed"),
+		WithHeader("X-API-Key", "spoofed"),
+		WithHeader("X-Secret-Key", "spoofed"),
+		WithHeader("X-Peer", "spoofed"),
+	}
+	basic, err := NewClient(server.URL, "v1", WithBasicAuth("user", "pass"))
+	assert.NoError(t, err)
+	anonymous, err := NewClient(server.URL, "v1")
+	assert.NoError(t, err)
+	_, err = basic.GetJob("1", spoofed...)
+	assert.NoError(t, err)
+	username, _, _ := last.BasicAuth()
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "cmd", last.Header.Get("X-Peer"))
+	assert.Empty(t, last.Header.Get("X-API-Key"))
+	assert.Empty(t, last.Header.Get("X-Secret-Key"))
+	_, err = anonymous.Healthcheck(spoofed...)
+	assert.NoError(t, err)
+	for _, header := range authHeaders {
+		assert.Empty(t, last.Header.Get(header), header)
+	}
+
+	start := time.Now()
+	_, err = client.GetJob("slow", WithTimeout(50*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// The timeout also applies to context-aware calls
+	_, err = client.updateJob(context.Background(), "slow", &JobUpdateRequestBody{}, WithTimeout(50*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCreateJobsIdempotent(t *testing.T) {
+	var (
+		stored []Job
+		keys   []string
+		posts  int
+		seen   = map[string]bool{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			resp := PaginatedJobsResponse{Success: true}
+			resp.Data.Jobs = stored
+			resp.Data.Total = len(stored)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+		posts++
+		key := r.Header.Get(IdempotencyKeyHeader)
+		keys = append(keys, key)
+		// Like a server honouring idempotency keys, a repeated key replays the first response
+		if seen[key] {
+			json.NewEncoder(w).Encode(BatchJobResponse{Success: true, Data: "req"})
+			return
+		}
+		seen[key] = true
+		var jobs []JobRequestBody
+		json.NewDecoder(r.Body).Decode(&jobs)
+		// Like a timed out request, only the first job is persisted on the first attempt
+		if posts == 1 {
+			jobs = jobs[:1]
+		}
+		for _, job := range jobs {
+			stored = append(stored, Job{ID: int64(len(stored) + 1), ProjectID: job.ProjectID, Spec: job.Spec, Timezone: job.Timezone, Data: job.Data})
+		}
+		json.NewEncoder(w).Encode(BatchJobResponse{Success: true, Data: "req"})
+	}))
+	defer server.Close()
+	client := createTestAPIClient(server)
+
+	jobs := []JobRequestBody{
+		{ProjectID: 1, Spec: "0 * * * * *", Data: `{"b": 2, "a": 1}`},
+		{ProjectID: 1, Spec: "0 * * * * *", Data: "plain"},
+		{ProjectID: 1, Spec: "0 * * * * *", Data: "plain"},
+	}
+
+	first, err := client.CreateJobsIdempotent(context.Background(), jobs, IdempotentCreateOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, first.Created, 3)
+	assert.Contains(t, first.Created[0].Data, JobFingerprintKey)
+	assert.Equal(t, "plain", first.Created[1].Data)
+	assert.Equal(t, JobFingerprint(jobs[0]), JobFingerprint(first.Created[0]))
+
+	second, err := client.CreateJobsIdempotent(context.Background(), jobs, IdempotentCreateOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, second.Skipped, 1)
+	assert.Len(t, second.Created, 2)
+	// The rerun sends fewer jobs, so it must not reuse the first attempt's key
+	assert.NotEqual(t, first.IdempotencyKey, second.IdempotencyKey)
+	assert.Equal(t, batchIdempotencyKey([]string{JobFingerprint(jobs[1]), JobFingerprint(jobs[2])}), second.IdempotencyKey)
+	assert.Len(t, stored, 3)
+
+	third, err := client.CreateJobsIdempotent(context.Background(), jobs, IdempotentCreateOptions{IdempotencyKey: "custom"})
+	assert.NoError(t, err)
+	assert.Nil(t, third.Response)
+	assert.Len(t, third.Skipped, 3)
+	assert.Equal(t, 2, posts)
+	assert.Equal(t, []string{first.IdempotencyKey, second.IdempotencyKey}, keys)
+
+	_, err = client.CreateJobsIdempotent(context.Background(), []JobRequestBody{{AccountID: 1}, {AccountID: 2}}, IdempotentCreateOptions{})
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+}
+
+func TestCreateJobsIdempotentScansTargetAccount(t *testing.T) {
+	stored := map[string][]Job{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account := r.Header.Get("X-Account-ID")
+		if r.Method == http.MethodGet {
+			resp := PaginatedJobsResponse{Success: true}
+			resp.Data.Jobs = stored[account]
+			resp.Data.Total = len(stored[account])
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+		var jobs []JobRequestBody
+		json.NewDecoder(r.Body).Decode(&jobs)
+		for _, job := range jobs {
+			stored[account] = append(stored[account], Job{ID: int64(len(stored[account]) + 1), ProjectID: job.ProjectID, Spec: job.Spec, Data: job.Data})
+		}
+		json.NewEncoder(w).Encode(BatchJobResponse{Success: true, Data: "req"})
+	}))
+	defer server.Close()
+	client := createTestAPIClient(server)
+
+	jobs := []JobRequestBody{{ProjectID: 1, Spec: "0 * * * * *", Data: `{"a":1}`}}
+	for i := 0; i < 2; i++ {
+		_, err := client.CreateJobsIdempotent(context.Background(), jobs, IdempotentCreateOptions{}, WithAccount("7"))
+		assert.NoError(t, err)
+	}
+	assert.Len(t, stored["7"], 1)
+	assert.Empty(t, stored["123"])
+
+	_, err := client.CreateJobsIdempotent(context.Background(), jobs, IdempotentCreateOptions{Jobs: ListJobsParams{AccountID: 8}}, WithAccount("7"))
+	assert.ErrorIs(t, err, ErrAccountMismatch)
+}
+
+func TestCreateJobsIdempotentComparesLiveFields(t *testing.T) {
+	base := JobRequestBody{ProjectID: 1, Spec: "0 * * * * *", Data: `{"a":1}`}
+	paused, offset := base, base
+	paused.Status = "paused"
+	offset.TimezoneOffset = 60
+	assert.NotEqual(t, JobFingerprint(base), JobFingerprint(paused))
+	assert.NotEqual(t, JobFingerprint(base), JobFingerprint(offset))
+	active := base
+	active.Status = "active"
+	assert.Equal(t, JobFingerprint(base), JobFingerprint(active))
+
+	var stored []Job
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			resp := PaginatedJobsResponse{Success: true}
+			resp.Data.Jobs = stored
+			resp.Data.Total = len(stored)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+		var jobs []JobRequestBody
+		json.NewDecoder(r.Body).Decode(&jobs)
+		for _, job := range jobs {
+			stored = append(stored, Job{ID: int64(len(stored) + 1), ProjectID: job.ProjectID, Spec: job.Spec, Data: job.Data,
+				Status: job.Status, TimezoneOffset: job.TimezoneOffset})
+		}
+		json.NewEncoder(w).Encode(BatchJobResponse{Success: true, Data: "req"})
+	}))
+	defer server.Close()
+	client := createTestAPIClient(server)
+
+	// Jobs differing only in status or offset are all created
+	result, err := client.CreateJobsIdempotent(context.Background(), []JobRequestBody{base, paused, offset}, IdempotentCreateOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, result.Created, 3)
+
+	// A job updated after it was created no longer stands for its batch job, despite its embedded fingerprint
+	stored[0].Spec = "0 0 * * * *"
+	result, err = client.CreateJobsIdempotent(context.Background(), []JobRequestBody{base, paused, offset}, IdempotentCreateOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, result.Skipped, 2)
+	assert.Len(t, result.Created, 1)
+	if assert.Len(t, result.Changed, 1) {
+		assert.Equal(t, int64(1), result.Changed[0].ID)
+	}
+}
+
+func TestRequestLimits(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+		throttle    bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		tooMany := throttle
+		throttle = false
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if tooMany {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "v1",
+		WithAPIKey("key", "secret"),
+		WithLimit(Limit{MaxInFlight: 2}),
+		WithOperationLimit("post jobs", Limit{Rate: 20, Burst: 1}),
+		WithDefaultAccountLimit(Limit{MaxInFlight: 1}),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetJob("1")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 2, maxInFlight)
+
+	// Each account may only have one request in flight
+	maxInFlight = 0
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetJob("1", WithAccount("42"))
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, maxInFlight)
+
+	// POST /jobs is limited to 20 requests per second
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.BatchCreateJobs([]JobRequestBody{{}})
+		assert.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	// A 429 pauses the client and halves the operation's rate
+	throttle = true
+	_, err := client.GetJob("1")
+	assert.Error(t, err)
+	bucket := client.limits.operations["POST /jobs"].bucket
+	start = time.Now()
+	_, err = client.GetJob("1")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	assert.Equal(t, 20.0, bucket.rate)
+
+	throttle = true
+	_, err = client.BatchCreateJobs([]JobRequestBody{{}})
+	assert.Error(t, err)
+	assert.Equal(t, 10.0, bucket.rate)
+
+	// Waiting respects the caller's context
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.batchCreateJobs(ctx, []JobRequestBody{{}})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.Equal(t, "GET /executions", client.operationName(httptest.NewRequest("GET", "/api/v1/executions/totals", nil)))
+	d, ok := retryAfter("3")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, d)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		failing = true
+		hits    int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		hits++
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	var changes []string
+	client, _ := NewClient(server.URL, "v1",
+		WithAPIKey("key", "secret"),
+		WithCircuitBreaker(CircuitBreakerConfig{
+			FailureThreshold: 3,
+			OpenTimeout:      50 * time.Millisecond,
+			OnStateChange: func(name string, from, to CircuitState) {
+				changes = append(changes, fmt.Sprintf("%s->%s", from, to))
+			},
+		}),
+	)
+	node := client.BaseURL.Host
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetJob("1")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrCircuitOpen)
+	}
+	assert.Equal(t, CircuitOpen, client.CircuitState(node))
+
+	// Open breakers fail fast without reaching the server
+	_, err := client.GetJob("1")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, hits)
+
+	// A failed trial reopens the breaker
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.GetJob("1")
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, CircuitOpen, client.CircuitState(node))
+
+	// Cancelled trials do not count
+	time.Sleep(60 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.updateJob(ctx, "1", &JobUpdateRequestBody{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, CircuitHalfOpen, client.CircuitState(node))
+
+	mu.Lock()
+	failing = false
+	mu.Unlock()
+	_, err = client.GetJob("1")
+	assert.NoError(t, err)
+	assert.Equal(t, CircuitClosed, client.CircuitState(node))
+	assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}, changes)
+
+	// Tenants share the breaker of their node; per-operation breakers are independent
+	tenant, err := client.ForAccount("7")
+	assert.NoError(t, err)
+	assert.Same(t, client.breakers, tenant.breakers)
+	perOperation, _ := NewClient(server.URL, "v1", WithCircuitBreaker(CircuitBreakerConfig{Scope: CircuitPerOperation, FailureThreshold: 1}))
+	mu.Lock()
+	failing = true
+	mu.Unlock()
+	_, _ = perOperation.GetJob("1")
+	assert.Equal(t, CircuitOpen, perOperation.CircuitState("GET /jobs"))
+	_, err = perOperation.ListProjects(ListProjectsParams{})
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+}
+
+type testPointerPayload struct {
+	Subject string `json:"subject"`
+}
+
+func (testPointerPayload) PayloadVersion() int { return 3 }
+func (testPointerPayload) PayloadType() string { return "pointer" }
+
+func TestTypedJobPointerPayload(t *testing.T) {
+	var sent []JobRequestBody
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		json.NewEncoder(w).Encode(BatchJobResponse{Success: true})
+	}))
+	defer server.Close()
+	client := createTestAPIClient(server)
+
+	data, err := EncodeJobData(&testPointerPayload{Subject: "weekly"})
+	assert.NoError(t, err)
+	assert.Equal(t, "pointer", JobDataType(Job{Data: data}))
+	assert.Equal(t, "pointer", PayloadTypeOf[*testPointerPayload]())
+
+	decoded, err := DecodeJobData[*testPointerPayload](Job{Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, "weekly", decoded.Subject)
+
+	_, err = CreateTypedJob(context.Background(), client, &JobRequestBody{ProjectID: 1}, &testPointerPayload{Subject: "daily"})
+	assert.NoError(t, err)
+	assert.Len(t, sent, 1)
+	assert.Contains(t, sent[0].Data, `"version":3`)
+}
+
+func TestThrottleBackoffWithoutLimits(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		throttle = true
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if throttle {
+			throttle = false
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	// No limit is configured, yet a 429 still pauses the client
+	client := createTestAPIClient(server)
+	_, err := client.GetJob("1")
+	assert.Error(t, err)
+	start := time.Now()
+	_, err = client.GetJob("1")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	// A tenant's own rate limit slows down on 429 as well, and the pause stays with the tenant
+	tenant, err := client.ForAccount("7", WithTenantRateLimit(100, 1))
+	assert.NoError(t, err)
+	mu.Lock()
+	throttle = true
+	mu.Unlock()
+	_, err = tenant.GetJob("1")
+	assert.Error(t, err)
+	assert.Equal(t, 50.0, tenant.limits.global.bucket.rate)
+	start = time.Now()
+	_, err = client.GetJob("1")
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 300*time.Millisecond)
+}
+
+func TestLimiterReturnsTokenWhenSlotWaitIsCancelled(t *testing.T) {
+	l := newLimiter(Limit{Rate: 1, Burst: 1, MaxInFlight: 1})
+	release, err := l.acquire(context.Background())
+	assert.NoError(t, err)
+	l.bucket.cancel() // refill the bucket so the next acquire only waits for the slot
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	release()
+
+	// The token taken by the cancelled acquire was returned
+	assert.Equal(t, time.Duration(0), l.bucket.reserve())
+}
+
+func TestCircuitBreakerIgnoresStaleOutcomes(t *testing.T) {
+	ctx := context.Background()
+	down := errors.New("connection refused")
+	b := &circuitBreakers{
+		cfg:      CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Millisecond, HalfOpenRequests: 2, IsFailure: defaultIsFailure},
+		circuits: map[string]*circuit{},
+	}
+	closedCall, _ := b.allow("node")
+	opening, _ := b.allow("node")
+	opening.done(ctx, nil, down)
+	time.Sleep(2 * time.Millisecond)
+
+	// slow is a trial of the first half-open period, which ends when the other trial fails
+	slow, err := b.allow("node")
+	assert.NoError(t, err)
+	failed, _ := b.allow("node")
+	failed.done(ctx, nil, down)
+	time.Sleep(2 * time.Millisecond)
+	current, err := b.allow("node")
+	assert.NoError(t, err)
+
+	// Outcomes from earlier periods neither count as trials nor free trial slots
+	slow.done(ctx, &http.Response{StatusCode: http.StatusOK}, nil)
+	closedCall.done(ctx, nil, down)
+	slow.cancel()
+	cb := b.circuits["node"]
+	assert.Equal(t, CircuitHalfOpen, cb.state)
+	assert.Equal(t, 1, cb.trials)
+	assert.Zero(t, cb.successes)
+
+	current.done(ctx, &http.Response{StatusCode: http.StatusOK}, nil)
+	last, err := b.allow("node")
+	assert.NoError(t, err)
+	last.done(ctx, &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.Equal(t, CircuitClosed, cb.state)
+}
+
+func TestCircuitBreakerIgnoresCallerDeadlines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "v1", WithAPIKey("key", "secret"), WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1}))
+	node := client.BaseURL.Host
+
+	_, err := client.GetJob("1", WithTimeout(10*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.AllJobs(ctx, ListJobsParams{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, CircuitClosed, client.CircuitState(node))
+
+	// The HTTP client's own timeout is the node's fault
+	client.HTTPClient.Timeout = 10 * time.Millisecond
+	_, err = client.GetJob("1")
+	assert.Error(t, err)
+	assert.Equal(t, CircuitOpen, client.CircuitState(node))
+}
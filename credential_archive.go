@@ -1,23 +1,25 @@
 package scheduler0_go_client
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // ArchiveCredential archives a credential by ID
-// accountIDOverride is optional - if provided, overrides the client's default account ID
-func (c *Client) ArchiveCredential(id string, archivedBy string, accountIDOverride ...string) error {
+// Pass WithAccount to override the client's default account ID
+func (c *Client) ArchiveCredential(id string, archivedBy string, opts ...RequestOption) error {
+	return c.archiveCredential(context.Background(), id, archivedBy, opts...)
+}
+
+func (c *Client) archiveCredential(ctx context.Context, id string, archivedBy string, opts ...RequestOption) error {
 	requestBody := map[string]string{
 		"archivedBy": archivedBy,
 	}
 
-	var accountID string
-	if len(accountIDOverride) > 0 {
-		accountID = accountIDOverride[0]
-	}
-
-	req, err := c.newRequest("POST", fmt.Sprintf("/credentials/%s/archive", id), requestBody, accountID)
+	req, err := c.newRequest("POST", fmt.Sprintf("/credentials/%s/archive", id), requestBody, opts...)
 	if err != nil {
 		return err
 	}
 
-	return c.do(req, nil)
+	return c.doContext(ctx, req, nil)
 }
@@ -0,0 +1,221 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Credential audit issues reported in CredentialFinding.Issues
+const (
+	CredentialIssueTooOld             = "too_old"
+	CredentialIssueArchivedNotDeleted = "archived_not_deleted"
+	CredentialIssueDepartedCreator    = "departed_creator"
+	CredentialIssueTooManyActive      = "too_many_active"
+)
+
+// Remediation actions in CredentialRemediation.Action
+const (
+	RemediationArchive = "archive"
+	RemediationDelete  = "delete"
+)
+
+// CredentialAuditOptions configures AuditCredentials
+type CredentialAuditOptions struct {
+	Params              ListCredentialsParams // Scope of the listing; Limit is the page size (defaults to 100)
+	MaxAge              time.Duration         // Active credentials created longer ago are flagged (0 disables)
+	DepartedUsers       []string              // CreatedBy values of users who have left, compared case-insensitively
+	MaxActivePerAccount int                   // Accounts with more active credentials have the oldest excess flagged (0 disables)
+	Now                 time.Time             // Reference time for ages (defaults to now)
+}
+
+// CredentialFinding lists the issues found with one credential
+type CredentialFinding struct {
+	Credential Credential
+	Age        time.Duration // Zero if DateCreated could not be parsed
+	Issues     []string
+}
+
+// AccountCredentialCount is the number of active credentials of an account over the limit
+type AccountCredentialCount struct {
+	AccountID int64
+	Active    int
+}
+
+// CredentialAuditReport is the result of AuditCredentials
+type CredentialAuditReport struct {
+	GeneratedAt     time.Time
+	Credentials     int // Credentials inspected
+	Findings        []CredentialFinding
+	CrowdedAccounts []AccountCredentialCount // Accounts over MaxActivePerAccount
+}
+
+// CredentialRemediation is a proposed fix for a finding
+type CredentialRemediation struct {
+	CredentialID int64
+	AccountID    int64
+	APIKey       string // Lets ApplyCredentialRemediation recognise the client's own credential
+	Action       string // RemediationArchive or RemediationDelete
+	Reason       string
+}
+
+// CredentialRemediationResult records the outcome of one remediation
+type CredentialRemediationResult struct {
+	CredentialRemediation
+	Applied bool
+	Skipped string // Why the action was not attempted
+	Err     error
+}
+
+// AuditCredentials lists every credential and reports hygiene issues
+// Active credentials (neither archived nor deleted) are checked for age, departed creators and
+// per-account limits; archived credentials that were never deleted are reported as well.
+func (c *Client) AuditCredentials(ctx context.Context, opts CredentialAuditOptions) (*CredentialAuditReport, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	departed := map[string]bool{}
+	for _, user := range opts.DepartedUsers {
+		departed[strings.ToLower(user)] = true
+	}
+
+	credentials, err := c.allCredentials(ctx, opts.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CredentialAuditReport{GeneratedAt: now, Credentials: len(credentials)}
+	findings := map[int64]*CredentialFinding{}
+	var order []int64
+	flag := func(credential Credential, age time.Duration, issue string) {
+		finding, ok := findings[credential.ID]
+		if !ok {
+			finding = &CredentialFinding{Credential: credential, Age: age}
+			findings[credential.ID] = finding
+			order = append(order, credential.ID)
+		}
+		finding.Issues = append(finding.Issues, issue)
+	}
+
+	active := map[int64][]Credential{}
+	ages := map[int64]time.Duration{}
+	for _, credential := range credentials {
+		if created, err := credential.ParsedDateCreated(); err == nil && !created.IsZero() {
+			ages[credential.ID] = now.Sub(created)
+		}
+		age := ages[credential.ID]
+
+		if credential.DateDeleted != nil {
+			continue
+		}
+		if credential.Archived {
+			flag(credential, age, CredentialIssueArchivedNotDeleted)
+			continue
+		}
+
+		active[credential.AccountID] = append(active[credential.AccountID], credential)
+		if opts.MaxAge > 0 && age > opts.MaxAge {
+			flag(credential, age, CredentialIssueTooOld)
+		}
+		if departed[strings.ToLower(credential.CreatedBy)] {
+			flag(credential, age, CredentialIssueDepartedCreator)
+		}
+	}
+
+	if opts.MaxActivePerAccount > 0 {
+		for accountID, keys := range active {
+			if len(keys) <= opts.MaxActivePerAccount {
+				continue
+			}
+			report.CrowdedAccounts = append(report.CrowdedAccounts, AccountCredentialCount{AccountID: accountID, Active: len(keys)})
+
+			// Flag the oldest credentials beyond the limit
+			sort.SliceStable(keys, func(i, j int) bool { return ages[keys[i].ID] > ages[keys[j].ID] })
+			for _, credential := range keys[:len(keys)-opts.MaxActivePerAccount] {
+				flag(credential, ages[credential.ID], CredentialIssueTooManyActive)
+			}
+		}
+		sort.Slice(report.CrowdedAccounts, func(i, j int) bool {
+			return report.CrowdedAccounts[i].AccountID < report.CrowdedAccounts[j].AccountID
+		})
+	}
+
+	for _, id := range order {
+		report.Findings = append(report.Findings, *findings[id])
+	}
+	return report, nil
+}
+
+// RemediationPlan proposes archiving flagged active credentials and deleting archived ones
+func (r *CredentialAuditReport) RemediationPlan() []CredentialRemediation {
+	var plan []CredentialRemediation
+	for _, finding := range r.Findings {
+		action := RemediationArchive
+		if finding.Credential.Archived {
+			action = RemediationDelete
+		}
+		plan = append(plan, CredentialRemediation{
+			CredentialID: finding.Credential.ID,
+			AccountID:    finding.Credential.AccountID,
+			APIKey:       finding.Credential.APIKey,
+			Action:       action,
+			Reason:       strings.Join(finding.Issues, ", "),
+		})
+	}
+	return plan
+}
+
+// ApplyCredentialRemediation archives or deletes credentials according to plan
+// Actions on the API key the client itself authenticates with are skipped so the client
+// cannot lock itself out. Clients using basic auth have no API key to match, so they get no
+// such guard. Failures are recorded on their result and do not stop the plan.
+func (c *Client) ApplyCredentialRemediation(ctx context.Context, plan []CredentialRemediation, actor string) ([]CredentialRemediationResult, error) {
+	own, err := c.currentCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []CredentialRemediationResult
+	for _, action := range plan {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		result := CredentialRemediationResult{CredentialRemediation: action}
+		id := strconv.FormatInt(action.CredentialID, 10)
+		switch {
+		case own.APIKey != "" && action.APIKey == own.APIKey:
+			result.Skipped = "credential is used by this client"
+		case action.Action == RemediationArchive:
+			result.Err = c.archiveCredential(ctx, id, actor, accountOverride(action.AccountID))
+		case action.Action == RemediationDelete:
+			result.Err = c.deleteCredential(ctx, id, &CredentialDeleteRequestBody{AccountID: action.AccountID, DeletedBy: actor})
+		default:
+			result.Err = fmt.Errorf("unknown remediation action %q", action.Action)
+		}
+		result.Applied = result.Skipped == "" && result.Err == nil
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// allCredentials pages through ListCredentials
+func (c *Client) allCredentials(ctx context.Context, params ListCredentialsParams) ([]Credential, error) {
+	if params.Limit <= 0 {
+		params.Limit = defaultPageSize
+	}
+	fetch := func(ctx context.Context, offset int) ([]Credential, int, error) {
+		params.Offset = offset
+		result, err := c.listCredentials(ctx, params)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Data.Credentials, result.Data.Total, nil
+	}
+	pages := newPager(ctx, params.Limit, params.Offset, fetch)
+	return pages.collect()
+}
@@ -1,17 +1,22 @@
 package scheduler0_go_client
 
+import "context"
+
 // CreateCredential creates a new credential
-func (c *Client) CreateCredential(body *CredentialCreateRequestBody) (*CredentialResponse, error) {
-	req, err := c.newRequest("POST", "/credentials", body)
+func (c *Client) CreateCredential(body *CredentialCreateRequestBody, opts ...RequestOption) (*CredentialResponse, error) {
+	return c.createCredential(context.Background(), body, opts...)
+}
+
+func (c *Client) createCredential(ctx context.Context, body *CredentialCreateRequestBody, opts ...RequestOption) (*CredentialResponse, error) {
+	req, err := c.newRequest("POST", "/credentials", body, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var result CredentialResponse
-	err = c.do(req, &result)
+	err = c.doContext(ctx, req, &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
-
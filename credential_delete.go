@@ -1,14 +1,20 @@
 package scheduler0_go_client
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // DeleteCredential deletes a credential by ID
-func (c *Client) DeleteCredential(id string, body *CredentialDeleteRequestBody) error {
-	req, err := c.newRequest("DELETE", fmt.Sprintf("/credentials/%s", id), body)
+func (c *Client) DeleteCredential(id string, body *CredentialDeleteRequestBody, opts ...RequestOption) error {
+	return c.deleteCredential(context.Background(), id, body, opts...)
+}
+
+func (c *Client) deleteCredential(ctx context.Context, id string, body *CredentialDeleteRequestBody, opts ...RequestOption) error {
+	req, err := c.newRequest("DELETE", fmt.Sprintf("/credentials/%s", id), body, opts...)
 	if err != nil {
 		return err
 	}
 
-	return c.do(req, nil)
+	return c.doContext(ctx, req, nil)
 }
-
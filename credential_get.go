@@ -1,19 +1,25 @@
 package scheduler0_go_client
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // GetCredential retrieves a single credential by ID
-func (c *Client) GetCredential(id string) (*CredentialResponse, error) {
-	req, err := c.newRequest("GET", fmt.Sprintf("/credentials/%s", id), nil)
+func (c *Client) GetCredential(id string, opts ...RequestOption) (*CredentialResponse, error) {
+	return c.getCredential(context.Background(), id, opts...)
+}
+
+func (c *Client) getCredential(ctx context.Context, id string, opts ...RequestOption) (*CredentialResponse, error) {
+	req, err := c.newRequest("GET", fmt.Sprintf("/credentials/%s", id), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var result CredentialResponse
-	err = c.do(req, &result)
+	err = c.doContext(ctx, req, &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
-
@@ -1,9 +1,16 @@
 package scheduler0_go_client
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // ListCredentials retrieves all credentials with optional query parameters
-func (c *Client) ListCredentials(params ListCredentialsParams) (*PaginatedCredentialsResponse, error) {
+func (c *Client) ListCredentials(params ListCredentialsParams, opts ...RequestOption) (*PaginatedCredentialsResponse, error) {
+	return c.listCredentials(context.Background(), params, opts...)
+}
+
+func (c *Client) listCredentials(ctx context.Context, params ListCredentialsParams, opts ...RequestOption) (*PaginatedCredentialsResponse, error) {
 	queryParams := map[string]string{
 		"limit":  fmt.Sprintf("%d", params.Limit),
 		"offset": fmt.Sprintf("%d", params.Offset),
@@ -16,18 +23,17 @@ func (c *Client) ListCredentials(params ListCredentialsParams) (*PaginatedCreden
 		queryParams["orderByDirection"] = params.OrderByDirection
 	}
 
-	var accountIDOverride string
-	if params.AccountID > 0 {
-		accountIDOverride = fmt.Sprintf("%d", params.AccountID)
+	opts, err := listAccountOptions(params.AccountID, opts)
+	if err != nil {
+		return nil, err
 	}
-
-	req, err := c.newRequestWithQuery("GET", "/credentials", nil, queryParams, accountIDOverride)
+	req, err := c.newRequestWithQuery("GET", "/credentials", nil, queryParams, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var result PaginatedCredentialsResponse
-	err = c.do(req, &result)
+	err = c.doContext(ctx, req, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,211 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Credential rotation steps recorded in RotationAuditEntry.Step
+const (
+	RotationStepCreate   = "create"
+	RotationStepVerify   = "verify"
+	RotationStepSwap     = "swap"
+	RotationStepGrace    = "grace_period"
+	RotationStepArchive  = "archive"
+	RotationStepDelete   = "delete"
+	RotationStepRollback = "rollback"
+)
+
+// RotationAuditEntry records one step of a credential rotation
+type RotationAuditEntry struct {
+	OldCredentialID int64     `json:"oldCredentialId"`
+	NewCredentialID int64     `json:"newCredentialId,omitempty"`
+	Step            string    `json:"step"`
+	Actor           string    `json:"actor"`
+	Message         string    `json:"message,omitempty"`
+	Error           string    `json:"error,omitempty"`
+	At              time.Time `json:"at"`
+}
+
+// RotationRecorder stores the audit trail of credential rotations
+type RotationRecorder interface {
+	RecordRotation(entry RotationAuditEntry) error
+}
+
+// RecordRotation writes entry as a single JSON line
+func (r *JSONLinesRecorder) RecordRotation(entry RotationAuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return json.NewEncoder(r.W).Encode(entry)
+}
+
+// RotateCredentialOptions configures RotateCredential
+type RotateCredentialOptions struct {
+	AccountID int64  // Account ID override (0 to use client default)
+	Actor     string // Recorded as CreatedBy, ArchivedBy and DeletedBy
+	// Clients are switched to the new credential with SetAPIKey; include the rotating client if it uses the old credential
+	// Clients configured with a CredentialsProvider have to be updated through the provider, e.g. in OnSwap.
+	Clients []*Client
+	// OnSwap is called after Clients are switched, e.g. to publish the new credential to a secret store
+	// An error restores the previous keys and rolls the rotation back.
+	OnSwap func(ctx context.Context, credential Credential) error
+	// GracePeriod is waited before archiving the old credential so other processes can pick up the new one
+	GracePeriod time.Duration
+	// DeleteOld deletes the old credential after archiving it
+	DeleteOld bool
+	Recorder  RotationRecorder // Optional; every step is also returned in the result
+}
+
+// CredentialRotation is the outcome of RotateCredential
+type CredentialRotation struct {
+	OldCredentialID int64
+	NewCredential   Credential
+	Audit           []RotationAuditEntry
+}
+
+// rotation carries the state of a running RotateCredential call
+type rotation struct {
+	opts   RotateCredentialOptions
+	result *CredentialRotation
+}
+
+func (r *rotation) record(step, message string, stepErr error) error {
+	entry := RotationAuditEntry{
+		OldCredentialID: r.result.OldCredentialID,
+		NewCredentialID: r.result.NewCredential.ID,
+		Step:            step,
+		Actor:           r.opts.Actor,
+		Message:         message,
+		At:              time.Now().UTC(),
+	}
+	if stepErr != nil {
+		entry.Error = stepErr.Error()
+	}
+	r.result.Audit = append(r.result.Audit, entry)
+
+	if r.opts.Recorder != nil {
+		if err := r.opts.Recorder.RecordRotation(entry); err != nil {
+			return errors.Join(stepErr, fmt.Errorf("failed to record rotation step %s: %w", step, err))
+		}
+	}
+	return stepErr
+}
+
+// RotateCredential replaces credential oldID without downtime
+// It creates a new credential, verifies it with an authenticated call, switches opts.Clients to it,
+// waits opts.GracePeriod and archives (and optionally deletes) the old credential. Every step is
+// recorded with opts.Recorder before the next one starts. If verification or OnSwap fails the new
+// credential is deleted and the old one is left untouched. If ctx is cancelled during the grace
+// period the new credential stays active and the old one is not archived.
+func (c *Client) RotateCredential(ctx context.Context, oldID int64, opts RotateCredentialOptions) (*CredentialRotation, error) {
+	r := &rotation{opts: opts, result: &CredentialRotation{OldCredentialID: oldID}}
+	oldIDString := strconv.FormatInt(oldID, 10)
+	account := accountOverride(opts.AccountID)
+
+	created, err := c.createCredential(ctx, &CredentialCreateRequestBody{AccountID: opts.AccountID, CreatedBy: opts.Actor})
+	if err != nil {
+		return r.result, r.record(RotationStepCreate, "", fmt.Errorf("failed to create credential: %w", err))
+	}
+	r.result.NewCredential = created.Data
+	if err := r.record(RotationStepCreate, fmt.Sprintf("created credential %d", created.Data.ID), nil); err != nil {
+		return r.result, err
+	}
+
+	if err := c.verifyCredential(ctx, created.Data); err != nil {
+		return r.result, c.rollbackRotation(ctx, r, r.record(RotationStepVerify, "", err))
+	}
+	if err := r.record(RotationStepVerify, "authenticated with the new credential", nil); err != nil {
+		return r.result, err
+	}
+
+	previous := make([][2]string, len(opts.Clients))
+	for i, client := range opts.Clients {
+		previous[i][0], previous[i][1] = client.apiKey()
+		client.SetAPIKey(created.Data.APIKey, created.Data.APISecret)
+	}
+	if opts.OnSwap != nil {
+		if err := opts.OnSwap(ctx, created.Data); err != nil {
+			for i, client := range opts.Clients {
+				client.SetAPIKey(previous[i][0], previous[i][1])
+			}
+			return r.result, c.rollbackRotation(ctx, r, r.record(RotationStepSwap, "restored previous keys", fmt.Errorf("swap hook failed: %w", err)))
+		}
+	}
+	if err := r.record(RotationStepSwap, fmt.Sprintf("switched %d clients", len(opts.Clients)), nil); err != nil {
+		return r.result, err
+	}
+
+	if opts.GracePeriod > 0 {
+		timer := time.NewTimer(opts.GracePeriod)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return r.result, r.record(RotationStepGrace, "old credential left active", ctx.Err())
+		case <-timer.C:
+		}
+		if err := r.record(RotationStepGrace, fmt.Sprintf("waited %s", opts.GracePeriod), nil); err != nil {
+			return r.result, err
+		}
+	}
+
+	if err := c.archiveCredential(ctx, oldIDString, opts.Actor, account); err != nil {
+		return r.result, r.record(RotationStepArchive, "", fmt.Errorf("failed to archive credential %d: %w", oldID, err))
+	}
+	if err := r.record(RotationStepArchive, fmt.Sprintf("archived credential %d", oldID), nil); err != nil {
+		return r.result, err
+	}
+
+	if opts.DeleteOld {
+		body := &CredentialDeleteRequestBody{AccountID: opts.AccountID, DeletedBy: opts.Actor}
+		if err := c.deleteCredential(ctx, oldIDString, body); err != nil {
+			return r.result, r.record(RotationStepDelete, "", fmt.Errorf("failed to delete credential %d: %w", oldID, err))
+		}
+		if err := r.record(RotationStepDelete, fmt.Sprintf("deleted credential %d", oldID), nil); err != nil {
+			return r.result, err
+		}
+	}
+	return r.result, nil
+}
+
+// verifyCredential makes an authenticated call with credential on a copy of the client
+func (c *Client) verifyCredential(ctx context.Context, credential Credential) error {
+	verifier := &Client{
+		BaseURL:    c.BaseURL,
+		HTTPClient: c.HTTPClient,
+		Version:    c.Version,
+		APIKey:     credential.APIKey,
+		APISecret:  credential.APISecret,
+		AccountID:  c.AccountID,
+		limits:     newClientLimits(c.limits),
+	}
+	if credential.AccountID > 0 {
+		verifier.AccountID = strconv.FormatInt(credential.AccountID, 10)
+	}
+
+	got, err := verifier.getCredential(ctx, strconv.FormatInt(credential.ID, 10))
+	if err != nil {
+		return fmt.Errorf("new credential failed to authenticate: %w", err)
+	}
+	if got.Data.ID != credential.ID {
+		return fmt.Errorf("verification returned credential %d, expected %d", got.Data.ID, credential.ID)
+	}
+	return nil
+}
+
+// rollbackRotation deletes the new credential after a failed rotation and returns cause
+func (c *Client) rollbackRotation(ctx context.Context, r *rotation, cause error) error {
+	id := r.result.NewCredential.ID
+	body := &CredentialDeleteRequestBody{AccountID: r.opts.AccountID, DeletedBy: r.opts.Actor}
+	if err := c.deleteCredential(ctx, strconv.FormatInt(id, 10), body); err != nil {
+		err = fmt.Errorf("failed to delete new credential %d: %w", id, err)
+		return errors.Join(cause, r.record(RotationStepRollback, "", err))
+	}
+	if err := r.record(RotationStepRollback, fmt.Sprintf("deleted new credential %d", id), nil); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
@@ -3,8 +3,8 @@ package scheduler0_go_client
 import "fmt"
 
 // UpdateCredential updates an existing credential
-func (c *Client) UpdateCredential(id string, body *CredentialUpdateRequestBody) (*CredentialResponse, error) {
-	req, err := c.newRequest("PUT", fmt.Sprintf("/credentials/%s", id), body)
+func (c *Client) UpdateCredential(id string, body *CredentialUpdateRequestBody, opts ...RequestOption) (*CredentialResponse, error) {
+	req, err := c.newRequest("PUT", fmt.Sprintf("/credentials/%s", id), body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,203 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Environment variables read by EnvCredentialsProvider
+const (
+	EnvAPIKey    = "SCHEDULER0_API_KEY"
+	EnvAPISecret = "SCHEDULER0_API_SECRET"
+	EnvUsername  = "SCHEDULER0_USERNAME"
+	EnvPassword  = "SCHEDULER0_PASSWORD"
+)
+
+// ErrNoCredentials is returned when a provider has no credentials to offer
+var ErrNoCredentials = errors.New("no credentials available")
+
+// Credentials authenticate requests, either with an API key and secret or with basic auth
+// Basic auth takes precedence when both are set, matching the Client fields.
+type Credentials struct {
+	APIKey    string `json:"apiKey"`
+	APISecret string `json:"apiSecret"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+}
+
+// empty reports whether the credentials can authenticate neither way
+func (c Credentials) empty() bool {
+	return (c.APIKey == "" || c.APISecret == "") && (c.Username == "" || c.Password == "")
+}
+
+// Format prints the credentials with APISecret and Password redacted for every verb
+func (c Credentials) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, c.redacted(), "Credentials")
+}
+
+// LogValue logs the credentials with APISecret and Password redacted
+func (c Credentials) LogValue() slog.Value {
+	return slog.AnyValue(c.redacted())
+}
+
+func (c Credentials) redacted() credentialsFields {
+	c.APISecret = redact(c.APISecret)
+	c.Password = redact(c.Password)
+	return credentialsFields(c)
+}
+
+// CredentialsProvider supplies the credentials of a client
+// It is consulted for every request, so implementations must be safe for concurrent use and
+// should be cheap; providers that fetch remotely are expected to cache.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// WithCredentialsProvider makes the client ask provider for credentials on every request
+// The provider takes precedence over APIKey, APISecret, Username and Password, which also
+// means SetAPIKey has no effect on requests while a provider is configured.
+func WithCredentialsProvider(provider CredentialsProvider) ClientOption {
+	return func(c *Client) {
+		c.credentials = provider
+	}
+}
+
+// StaticCredentialsProvider returns fixed credentials
+type StaticCredentialsProvider struct {
+	Value Credentials
+}
+
+// Credentials returns p.Value
+func (p StaticCredentialsProvider) Credentials(context.Context) (Credentials, error) {
+	if p.Value.empty() {
+		return Credentials{}, ErrNoCredentials
+	}
+	return p.Value, nil
+}
+
+// EnvCredentialsProvider reads credentials from the SCHEDULER0_* environment variables on every call
+type EnvCredentialsProvider struct{}
+
+// Credentials reads EnvAPIKey, EnvAPISecret, EnvUsername and EnvPassword
+func (EnvCredentialsProvider) Credentials(context.Context) (Credentials, error) {
+	creds := Credentials{
+		APIKey:    os.Getenv(EnvAPIKey),
+		APISecret: os.Getenv(EnvAPISecret),
+		Username:  os.Getenv(EnvUsername),
+		Password:  os.Getenv(EnvPassword),
+	}
+	if creds.empty() {
+		return Credentials{}, fmt.Errorf("%w: set %s and %s or %s and %s", ErrNoCredentials, EnvAPIKey, EnvAPISecret, EnvUsername, EnvPassword)
+	}
+	return creds, nil
+}
+
+// FileCredentialsProvider reads credentials from a JSON file and reloads it when it changes
+// The file holds a Credentials object, e.g. {"apiKey": "...", "apiSecret": "..."}. The file is
+// checked at most once per CheckInterval; if a changed file cannot be parsed (for instance
+// while it is being rewritten) the last good credentials keep being used and the file is read
+// again on the next check. A file written from redacted output, holding "[REDACTED]" as a
+// secret, is rejected the same way.
+type FileCredentialsProvider struct {
+	path          string
+	checkInterval time.Duration
+
+	mu        sync.Mutex
+	creds     Credentials
+	loaded    bool
+	modTime   time.Time
+	size      int64
+	lastCheck time.Time
+}
+
+// NewFileCredentialsProvider loads path and returns a provider that watches it
+// checkInterval limits how often the file is stat'ed (0 checks on every request).
+func NewFileCredentialsProvider(path string, checkInterval time.Duration) (*FileCredentialsProvider, error) {
+	p := &FileCredentialsProvider{path: path, checkInterval: checkInterval}
+	if err := p.reload(time.Now()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Credentials returns the current file contents, reloading them if the file changed
+func (p *FileCredentialsProvider) Credentials(context.Context) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if !p.loaded || now.Sub(p.lastCheck) >= p.checkInterval {
+		if err := p.reload(now); err != nil && !p.loaded {
+			return Credentials{}, err
+		}
+	}
+	return p.creds, nil
+}
+
+// reload re-reads the file if its modification time or size changed; callers hold p.mu
+func (p *FileCredentialsProvider) reload(now time.Time) error {
+	p.lastCheck = now
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return err
+	}
+	if p.loaded && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return err
+	}
+	var creds Credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return fmt.Errorf("invalid credentials file %s: %w", p.path, err)
+	}
+	if creds.empty() {
+		return fmt.Errorf("%w in %s", ErrNoCredentials, p.path)
+	}
+	if creds.APISecret == redactedValue || creds.Password == redactedValue {
+		return fmt.Errorf("credentials file %s holds a redacted secret", p.path)
+	}
+
+	p.creds = creds
+	p.loaded = true
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	return nil
+}
+
+// ChainCredentialsProvider returns the credentials of the first provider that has any
+type ChainCredentialsProvider []CredentialsProvider
+
+// Credentials tries each provider in order, returning all errors if none succeeds
+func (chain ChainCredentialsProvider) Credentials(ctx context.Context) (Credentials, error) {
+	errs := []error{ErrNoCredentials}
+	for _, provider := range chain {
+		creds, err := provider.Credentials(ctx)
+		if err == nil && !creds.empty() {
+			return creds, nil
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return Credentials{}, errors.Join(errs...)
+}
+
+// currentCredentials returns the provider's credentials, or the client fields without a provider
+func (c *Client) currentCredentials(ctx context.Context) (Credentials, error) {
+	if c.credentials != nil {
+		return c.credentials.Credentials(ctx)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return Credentials{APIKey: c.APIKey, APISecret: c.APISecret, Username: c.Username, Password: c.Password}, nil
+}
@@ -0,0 +1,174 @@
+package scheduler0_go_client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// serverTimeLayouts lists the date formats emitted by the Scheduler0 server, most common first
+var serverTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999 -0700",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// ParseTime parses a date string returned by the API
+// An empty string yields the zero time and no error. Values without a zone are treated as UTC.
+func ParseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	// Go's time.String() appends a monotonic clock reading that cannot be parsed
+	if i := strings.Index(value, " m="); i != -1 {
+		value = value[:i]
+	}
+
+	for _, layout := range serverTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time format: %q", value)
+}
+
+// FormatTime formats a time in the RFC3339 UTC form expected by request bodies and query parameters
+// The zero time is formatted as an empty string so the field is omitted.
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseOptionalTime(value *string) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, nil
+	}
+	return ParseTime(*value)
+}
+
+// Request-side helpers
+
+// SetStartDate sets StartDate from a time value
+func (b *JobRequestBody) SetStartDate(t time.Time) {
+	b.StartDate = FormatTime(t)
+}
+
+// SetEndDate sets EndDate from a time value
+func (b *JobRequestBody) SetEndDate(t time.Time) {
+	b.EndDate = FormatTime(t)
+}
+
+// SetStartDate sets StartDate from a time value
+func (b *JobUpdateRequestBody) SetStartDate(t time.Time) {
+	b.StartDate = FormatTime(t)
+}
+
+// SetEndDate sets EndDate from a time value
+func (b *JobUpdateRequestBody) SetEndDate(t time.Time) {
+	b.EndDate = FormatTime(t)
+}
+
+// SetDateRange sets StartDate and EndDate from time values
+func (p *ListExecutionsParams) SetDateRange(start, end time.Time) {
+	p.StartDate = FormatTime(start)
+	p.EndDate = FormatTime(end)
+}
+
+// Job accessors
+
+// ParsedStartDate returns StartDate as a time value
+func (j Job) ParsedStartDate() (time.Time, error) { return ParseTime(j.StartDate) }
+
+// ParsedEndDate returns EndDate as a time value
+func (j Job) ParsedEndDate() (time.Time, error) { return ParseTime(j.EndDate) }
+
+// ParsedLastExecutionDate returns LastExecutionDate as a time value
+func (j Job) ParsedLastExecutionDate() (time.Time, error) { return ParseTime(j.LastExecutionDate) }
+
+// ParsedDateCreated returns DateCreated as a time value
+func (j Job) ParsedDateCreated() (time.Time, error) { return ParseTime(j.DateCreated) }
+
+// ParsedDateModified returns DateModified as a time value (zero if unset)
+func (j Job) ParsedDateModified() (time.Time, error) { return parseOptionalTime(j.DateModified) }
+
+// Execution accessors
+
+// ParsedLastExecutionDatetime returns LastExecutionDatetime as a time value
+func (e Execution) ParsedLastExecutionDatetime() (time.Time, error) {
+	return ParseTime(e.LastExecutionDatetime)
+}
+
+// ParsedNextExecutionDatetime returns NextExecutionDatetime as a time value
+func (e Execution) ParsedNextExecutionDatetime() (time.Time, error) {
+	return ParseTime(e.NextExecutionDatetime)
+}
+
+// ParsedDateCreated returns DateCreated as a time value
+func (e Execution) ParsedDateCreated() (time.Time, error) { return ParseTime(e.DateCreated) }
+
+// ParsedDateModified returns DateModified as a time value (zero if unset)
+func (e Execution) ParsedDateModified() (time.Time, error) { return parseOptionalTime(e.DateModified) }
+
+// Project accessors
+
+// ParsedDateCreated returns DateCreated as a time value
+func (p Project) ParsedDateCreated() (time.Time, error) { return ParseTime(p.DateCreated) }
+
+// ParsedDateModified returns DateModified as a time value (zero if unset)
+func (p Project) ParsedDateModified() (time.Time, error) { return parseOptionalTime(p.DateModified) }
+
+// Credential accessors
+
+// ParsedDateCreated returns DateCreated as a time value
+func (c Credential) ParsedDateCreated() (time.Time, error) { return ParseTime(c.DateCreated) }
+
+// ParsedDateModified returns DateModified as a time value (zero if unset)
+func (c Credential) ParsedDateModified() (time.Time, error) { return parseOptionalTime(c.DateModified) }
+
+// ParsedDateDeleted returns DateDeleted as a time value (zero if unset)
+func (c Credential) ParsedDateDeleted() (time.Time, error) { return parseOptionalTime(c.DateDeleted) }
+
+// Executor accessors
+
+// ParsedDateCreated returns DateCreated as a time value
+func (e Executor) ParsedDateCreated() (time.Time, error) { return ParseTime(e.DateCreated) }
+
+// ParsedDateModified returns DateModified as a time value (zero if unset)
+func (e Executor) ParsedDateModified() (time.Time, error) { return parseOptionalTime(e.DateModified) }
+
+// ParsedDateDeleted returns DateDeleted as a time value (zero if unset)
+func (e Executor) ParsedDateDeleted() (time.Time, error) { return parseOptionalTime(e.DateDeleted) }
+
+// Account accessors
+
+// ParsedDateCreated returns DateCreated as a time value
+func (a Account) ParsedDateCreated() (time.Time, error) { return ParseTime(a.DateCreated) }
+
+// ParsedDateModified returns DateModified as a time value (zero if unset)
+func (a Account) ParsedDateModified() (time.Time, error) { return parseOptionalTime(a.DateModified) }
+
+// AccountJobExecutionsCount accessors
+
+// ParsedDateCreated returns DateCreated as a time value
+func (a AccountJobExecutionsCount) ParsedDateCreated() (time.Time, error) {
+	return ParseTime(a.DateCreated)
+}
+
+// ParsedDateModified returns DateModified as a time value
+func (a AccountJobExecutionsCount) ParsedDateModified() (time.Time, error) {
+	return ParseTime(a.DateModified)
+}
+
+// ParsedNextResetDate returns NextResetDate as a time value
+func (a AccountJobExecutionsCount) ParsedNextResetDate() (time.Time, error) {
+	return ParseTime(a.NextResetDate)
+}
@@ -0,0 +1,216 @@
+package scheduler0_go_client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ExportFormat selects the output format of ExportExecutions
+type ExportFormat string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportNDJSON ExportFormat = "ndjson"
+)
+
+// exportColumn renders one execution field; names match the JSON field names of Execution
+type exportColumn struct {
+	name  string
+	value func(e Execution, loc *time.Location) interface{}
+}
+
+// exportColumns lists every exportable field in output order
+var exportColumns = []exportColumn{
+	{"id", func(e Execution, _ *time.Location) interface{} { return e.ID }},
+	{"accountId", func(e Execution, _ *time.Location) interface{} { return e.AccountID }},
+	{"uniqueId", func(e Execution, _ *time.Location) interface{} { return e.UniqueID }},
+	{"state", func(e Execution, _ *time.Location) interface{} { return e.State }},
+	{"nodeId", func(e Execution, _ *time.Location) interface{} { return e.NodeID }},
+	{"jobId", func(e Execution, _ *time.Location) interface{} { return e.JobID }},
+	{"lastExecutionDatetime", func(e Execution, loc *time.Location) interface{} {
+		return convertExportTime(e.LastExecutionDatetime, loc)
+	}},
+	{"nextExecutionDatetime", func(e Execution, loc *time.Location) interface{} {
+		return convertExportTime(e.NextExecutionDatetime, loc)
+	}},
+	{"jobQueueVersion", func(e Execution, _ *time.Location) interface{} { return e.JobQueueVersion }},
+	{"executionVersion", func(e Execution, _ *time.Location) interface{} { return e.ExecutionVersion }},
+	{"dateCreated", func(e Execution, loc *time.Location) interface{} { return convertExportTime(e.DateCreated, loc) }},
+	{"dateModified", func(e Execution, loc *time.Location) interface{} {
+		if e.DateModified == nil {
+			return nil
+		}
+		return convertExportTime(*e.DateModified, loc)
+	}},
+}
+
+// ExportFields returns the field names accepted by WithExportFields, in output order
+func ExportFields() []string {
+	names := make([]string, len(exportColumns))
+	for i, column := range exportColumns {
+		names[i] = column.name
+	}
+	return names
+}
+
+type exportConfig struct {
+	fields   []string
+	location *time.Location
+}
+
+// ExportOption configures ExportExecutions
+type ExportOption func(*exportConfig)
+
+// WithExportFields limits the export to the given fields, written in the given order
+func WithExportFields(fields ...string) ExportOption {
+	return func(cfg *exportConfig) {
+		cfg.fields = fields
+	}
+}
+
+// WithExportLocation converts date fields to loc; by default they are written as returned by the API
+func WithExportLocation(loc *time.Location) ExportOption {
+	return func(cfg *exportConfig) {
+		cfg.location = loc
+	}
+}
+
+// ExportExecutions streams every execution matching params to w as CSV or NDJSON
+// Pages are written as they are fetched, so the full history is never held in memory.
+// It returns the number of executions written.
+func (c *Client) ExportExecutions(ctx context.Context, params ListExecutionsParams, w io.Writer, format ExportFormat, opts ...ExportOption) (int, error) {
+	var cfg exportConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	columns, err := selectExportColumns(cfg.fields)
+	if err != nil {
+		return 0, err
+	}
+
+	var write func(e Execution) error
+	var flush func() error
+	switch format {
+	case ExportCSV:
+		writer := csv.NewWriter(w)
+		header := make([]string, len(columns))
+		for i, column := range columns {
+			header[i] = column.name
+		}
+		if err := writer.Write(header); err != nil {
+			return 0, err
+		}
+		write = func(e Execution) error {
+			row := make([]string, len(columns))
+			for i, column := range columns {
+				row[i] = formatCSVValue(column.value(e, cfg.location))
+			}
+			return writer.Write(row)
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case ExportNDJSON:
+		writer := bufio.NewWriter(w)
+		write = func(e Execution) error {
+			line, err := marshalOrderedJSON(columns, e, cfg.location)
+			if err != nil {
+				return err
+			}
+			_, err = writer.Write(append(line, '\n'))
+			return err
+		}
+		flush = writer.Flush
+	default:
+		return 0, fmt.Errorf("unsupported export format: %q", format)
+	}
+
+	count := 0
+	it := c.NewExecutionIterator(ctx, params)
+	for it.Next() {
+		if err := write(it.Execution()); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := flush(); err != nil {
+		return count, err
+	}
+	return count, it.Err()
+}
+
+func selectExportColumns(fields []string) ([]exportColumn, error) {
+	if len(fields) == 0 {
+		return exportColumns, nil
+	}
+
+	byName := map[string]exportColumn{}
+	for _, column := range exportColumns {
+		byName[column.name] = column
+	}
+
+	columns := make([]exportColumn, 0, len(fields))
+	for _, field := range fields {
+		column, ok := byName[field]
+		if !ok {
+			return nil, fmt.Errorf("unknown export field: %q", field)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// marshalOrderedJSON encodes the selected columns as a JSON object, preserving column order
+func marshalOrderedJSON(columns []exportColumn, e Execution, loc *time.Location) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, column := range columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(column.name)
+		value, err := json.Marshal(column.value(e, loc))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func formatCSVValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// convertExportTime reformats value in loc, leaving it untouched if loc is nil or it cannot be parsed
+func convertExportTime(value string, loc *time.Location) string {
+	if loc == nil || value == "" {
+		return value
+	}
+	t, err := ParseTime(value)
+	if err != nil {
+		return value
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
@@ -0,0 +1,46 @@
+package scheduler0_go_client
+
+import "context"
+
+// ExecutionIterator walks every execution matching ListExecutionsParams, fetching pages on demand
+type ExecutionIterator struct {
+	pager pager[Execution]
+}
+
+// NewExecutionIterator creates an iterator over all executions matching params
+// params.Limit is used as the page size and params.Offset as the starting offset.
+func (c *Client) NewExecutionIterator(ctx context.Context, params ListExecutionsParams) *ExecutionIterator {
+	if params.Limit <= 0 {
+		params.Limit = defaultPageSize
+	}
+	fetch := func(ctx context.Context, offset int) ([]Execution, int, error) {
+		params.Offset = offset
+		result, err := c.listExecutions(ctx, params)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Data.Executions, result.Data.Total, nil
+	}
+	return &ExecutionIterator{pager: newPager(ctx, params.Limit, params.Offset, fetch)}
+}
+
+// Next advances to the next execution, fetching the next page when needed
+// It returns false when all executions have been read or an error occurred.
+func (it *ExecutionIterator) Next() bool {
+	return it.pager.next()
+}
+
+// Execution returns the execution at the current position
+func (it *ExecutionIterator) Execution() Execution {
+	return it.pager.current
+}
+
+// Err returns the error that stopped iteration, if any
+func (it *ExecutionIterator) Err() error {
+	return it.pager.err
+}
+
+// AllExecutions collects every execution matching params
+func (c *Client) AllExecutions(ctx context.Context, params ListExecutionsParams) ([]Execution, error) {
+	return c.NewExecutionIterator(ctx, params).pager.collect()
+}
@@ -1,9 +1,16 @@
 package scheduler0_go_client
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // ListExecutions retrieves job executions with query parameters
-func (c *Client) ListExecutions(params ListExecutionsParams) (*PaginatedExecutionsResponse, error) {
+func (c *Client) ListExecutions(params ListExecutionsParams, opts ...RequestOption) (*PaginatedExecutionsResponse, error) {
+	return c.listExecutions(context.Background(), params, opts...)
+}
+
+func (c *Client) listExecutions(ctx context.Context, params ListExecutionsParams, opts ...RequestOption) (*PaginatedExecutionsResponse, error) {
 	queryParams := map[string]string{
 		"limit":  fmt.Sprintf("%d", params.Limit),
 		"offset": fmt.Sprintf("%d", params.Offset),
@@ -33,18 +40,17 @@ func (c *Client) ListExecutions(params ListExecutionsParams) (*PaginatedExecutio
 		queryParams["orderDirection"] = params.OrderDirection
 	}
 
-	var accountIDOverride string
-	if params.AccountID > 0 {
-		accountIDOverride = fmt.Sprintf("%d", params.AccountID)
+	opts, err := listAccountOptions(params.AccountID, opts)
+	if err != nil {
+		return nil, err
 	}
-
-	req, err := c.newRequestWithQuery("GET", "/executions", nil, queryParams, accountIDOverride)
+	req, err := c.newRequestWithQuery("GET", "/executions", nil, queryParams, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var result PaginatedExecutionsResponse
-	err = c.do(req, &result)
+	err = c.doContext(ctx, req, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -53,18 +59,17 @@ func (c *Client) ListExecutions(params ListExecutionsParams) (*PaginatedExecutio
 
 // GetDateRangeAnalytics retrieves execution counts grouped by minute buckets for a date range
 // All dates and times should be in UTC (timezone conversion should be done on frontend)
-func (c *Client) GetDateRangeAnalytics(params GetDateRangeAnalyticsParams) (*DateRangeAnalyticsAPIResponse, error) {
+func (c *Client) GetDateRangeAnalytics(params GetDateRangeAnalyticsParams, opts ...RequestOption) (*DateRangeAnalyticsAPIResponse, error) {
 	queryParams := map[string]string{
 		"startDate": params.StartDate,
 		"startTime": params.StartTime,
 	}
 
-	var accountIDOverride string
-	if params.AccountID > 0 {
-		accountIDOverride = fmt.Sprintf("%d", params.AccountID)
+	opts, err := listAccountOptions(params.AccountID, opts)
+	if err != nil {
+		return nil, err
 	}
-
-	req, err := c.newRequestWithQuery("GET", "/executions/analytics", nil, queryParams, accountIDOverride)
+	req, err := c.newRequestWithQuery("GET", "/executions/analytics", nil, queryParams, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -79,13 +84,12 @@ func (c *Client) GetDateRangeAnalytics(params GetDateRangeAnalyticsParams) (*Dat
 }
 
 // GetExecutionTotals retrieves total counts of scheduled, success, and failed executions for an account
-func (c *Client) GetExecutionTotals(accountID int64) (*ExecutionTotalsAPIResponse, error) {
-	var accountIDOverride string
-	if accountID > 0 {
-		accountIDOverride = fmt.Sprintf("%d", accountID)
+func (c *Client) GetExecutionTotals(accountID int64, opts ...RequestOption) (*ExecutionTotalsAPIResponse, error) {
+	opts, err := listAccountOptions(accountID, opts)
+	if err != nil {
+		return nil, err
 	}
-
-	req, err := c.newRequestWithQuery("GET", "/executions/totals", nil, nil, accountIDOverride)
+	req, err := c.newRequestWithQuery("GET", "/executions/totals", nil, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -100,27 +104,26 @@ func (c *Client) GetExecutionTotals(accountID int64) (*ExecutionTotalsAPIRespons
 }
 
 // CleanupOldExecutionLogs cleans up old execution logs for an account based on retention period
-// accountIDOverride is optional - if provided, overrides the client's default account ID
-func (c *Client) CleanupOldExecutionLogs(accountID string, retentionMonths int, accountIDOverride ...string) (*CleanupOldLogsResponse, error) {
+// Pass WithAccount to override the client's default account ID
+func (c *Client) CleanupOldExecutionLogs(accountID string, retentionMonths int, opts ...RequestOption) (*CleanupOldLogsResponse, error) {
+	return c.cleanupOldExecutionLogs(context.Background(), accountID, retentionMonths, opts...)
+}
+
+func (c *Client) cleanupOldExecutionLogs(ctx context.Context, accountID string, retentionMonths int, opts ...RequestOption) (*CleanupOldLogsResponse, error) {
 	requestBody := CleanupOldLogsRequestBody{
 		AccountID:       accountID,
 		RetentionMonths: retentionMonths,
 	}
 
-	var accountIDHeader string
-	if len(accountIDOverride) > 0 && accountIDOverride[0] != "" {
-		accountIDHeader = accountIDOverride[0]
-	} else {
-		accountIDHeader = accountID
-	}
-
-	req, err := c.newRequest("POST", "/executions/cleanup-old-logs", requestBody, accountIDHeader)
+	// The account being cleaned up is the default, a WithAccount option still takes precedence
+	opts = append([]RequestOption{WithAccount(accountID)}, opts...)
+	req, err := c.newRequest("POST", "/executions/cleanup-old-logs", requestBody, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var result CleanupOldLogsResponse
-	err = c.do(req, &result)
+	err = c.doContext(ctx, req, &result)
 	if err != nil {
 		return nil, err
 	}
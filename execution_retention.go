@@ -0,0 +1,172 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRetentionBelowFloor is returned when a retention policy keeps logs for less than the safety floor
+var ErrRetentionBelowFloor = errors.New("retention below safety floor")
+
+// RetentionPolicy sets how many months of execution logs to keep for an account
+type RetentionPolicy struct {
+	AccountID       string `json:"accountId"`
+	RetentionMonths int    `json:"retentionMonths"`
+}
+
+// RetentionConfig is the configuration of a RetentionManager
+type RetentionConfig struct {
+	MinRetentionMonths int               `json:"minRetentionMonths"` // Safety floor; policies below it are refused (at least 1)
+	Policies           []RetentionPolicy `json:"policies"`
+}
+
+// LoadRetentionConfig reads a RetentionConfig from a JSON file
+func LoadRetentionConfig(path string) (*RetentionConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config RetentionConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid retention config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// Validate checks that every policy has an account and respects the safety floor
+func (cfg *RetentionConfig) Validate() error {
+	floor := cfg.MinRetentionMonths
+	if floor < 1 {
+		floor = 1
+	}
+
+	seen := map[string]bool{}
+	for _, policy := range cfg.Policies {
+		if policy.AccountID == "" {
+			return errors.New("retention policy is missing an account ID")
+		}
+		if _, err := strconv.ParseInt(policy.AccountID, 10, 64); err != nil {
+			return fmt.Errorf("invalid account ID %q in retention policy", policy.AccountID)
+		}
+		if seen[policy.AccountID] {
+			return fmt.Errorf("duplicate retention policy for account %s", policy.AccountID)
+		}
+		seen[policy.AccountID] = true
+		if policy.RetentionMonths < floor {
+			return fmt.Errorf("%w: account %s keeps %d months, floor is %d", ErrRetentionBelowFloor, policy.AccountID, policy.RetentionMonths, floor)
+		}
+	}
+	return nil
+}
+
+// RetentionResult records what a retention run did for one account
+type RetentionResult struct {
+	AccountID          string    `json:"accountId"`
+	RetentionMonths    int       `json:"retentionMonths"`
+	Cutoff             time.Time `json:"cutoff"`
+	AffectedExecutions int       `json:"affectedExecutions"` // Executions older than Cutoff before cleanup
+	DryRun             bool      `json:"dryRun"`
+	Cleaned            bool      `json:"cleaned"`
+	Message            string    `json:"message,omitempty"`
+	Error              string    `json:"error,omitempty"`
+	RanAt              time.Time `json:"ranAt"`
+}
+
+// RetentionRecorder stores the results of retention runs
+type RetentionRecorder interface {
+	Record(result RetentionResult) error
+}
+
+// JSONLinesRecorder appends each result as a JSON line to W
+type JSONLinesRecorder struct {
+	W  io.Writer
+	mu sync.Mutex
+}
+
+// Record writes result as a single JSON line
+func (r *JSONLinesRecorder) Record(result RetentionResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return json.NewEncoder(r.W).Encode(result)
+}
+
+// RetentionManager applies per-account execution log retention policies
+type RetentionManager struct {
+	client   *Client
+	config   RetentionConfig
+	recorder RetentionRecorder
+}
+
+// NewRetentionManager creates a manager for config, refusing policies below the safety floor
+// recorder may be nil if results only need to be returned.
+func NewRetentionManager(client *Client, config RetentionConfig, recorder RetentionRecorder) (*RetentionManager, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &RetentionManager{client: client, config: config, recorder: recorder}, nil
+}
+
+// Apply runs every policy in order
+// In dry-run mode executions older than each cutoff are counted with ListExecutions but nothing
+// is deleted. Failures for one account are recorded on its result and do not stop the others.
+func (m *RetentionManager) Apply(ctx context.Context, dryRun bool) ([]RetentionResult, error) {
+	var results []RetentionResult
+	for _, policy := range m.config.Policies {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		result := m.applyPolicy(ctx, policy, dryRun)
+		if m.recorder != nil {
+			if err := m.recorder.Record(result); err != nil {
+				return results, fmt.Errorf("failed to record retention result for account %s: %w", policy.AccountID, err)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (m *RetentionManager) applyPolicy(ctx context.Context, policy RetentionPolicy, dryRun bool) RetentionResult {
+	now := time.Now().UTC()
+	result := RetentionResult{
+		AccountID:       policy.AccountID,
+		RetentionMonths: policy.RetentionMonths,
+		Cutoff:          now.AddDate(0, -policy.RetentionMonths, 0),
+		DryRun:          dryRun,
+		RanAt:           now,
+	}
+
+	accountID, _ := strconv.ParseInt(policy.AccountID, 10, 64)
+	count, err := m.client.listExecutions(ctx, ListExecutionsParams{
+		EndDate:   FormatTime(result.Cutoff),
+		AccountID: accountID,
+		Limit:     1,
+	})
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to count executions: %v", err)
+		return result
+	}
+	result.AffectedExecutions = count.Data.Total
+
+	if dryRun {
+		return result
+	}
+
+	cleaned, err := m.client.cleanupOldExecutionLogs(ctx, policy.AccountID, policy.RetentionMonths)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to clean up execution logs: %v", err)
+		return result
+	}
+	result.Cleaned = true
+	result.Message = cleaned.Data.Message
+	return result
+}
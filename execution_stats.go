@@ -0,0 +1,156 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// ExecutionStats summarises the executions of a job or project over a window
+type ExecutionStats struct {
+	JobID                int64
+	ProjectID            int64
+	From                 time.Time
+	To                   time.Time
+	Total                int              // Runs in the window, counting each UniqueID once
+	CountsByState        map[int64]int    // Runs per Execution.State
+	Succeeded            int              // Runs in ExecutionStateSuccess
+	Failed               int              // Runs in ExecutionStateFailed
+	SuccessRatio         float64          // Succeeded / (Succeeded + Failed)
+	AverageDrift         time.Duration    // Mean delay between the scheduled time and LastExecutionDatetime
+	MaxDrift             time.Duration    // Largest delay between the scheduled time and LastExecutionDatetime
+	LastFailure          *Execution       // Most recent failed run
+	LongestFailureStreak int              // Most consecutive failed runs
+	CurrentFailureStreak int              // Failed runs still unbroken by a success, for the job of LastFailure
+	StateFlips           int              // Changes between success and failure; high values indicate flapping
+	Jobs                 []ExecutionStats // Per-job breakdown for ProjectStats, most failures first
+}
+
+// JobStats computes execution statistics for a job over the trailing window
+func (c *Client) JobStats(ctx context.Context, jobID int64, window time.Duration) (*ExecutionStats, error) {
+	to := time.Now().UTC()
+	from := to.Add(-window)
+
+	executions, err := c.AllExecutions(ctx, ListExecutionsParams{
+		StartDate: FormatTime(from),
+		EndDate:   FormatTime(to),
+		JobID:     jobID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := ComputeExecutionStats(executions)
+	stats.JobID = jobID
+	stats.From, stats.To = from, to
+	return stats, nil
+}
+
+// ProjectStats computes execution statistics for a project over the trailing window,
+// with a per-job breakdown so flapping jobs stand out
+func (c *Client) ProjectStats(ctx context.Context, projectID int64, window time.Duration) (*ExecutionStats, error) {
+	to := time.Now().UTC()
+	from := to.Add(-window)
+
+	executions, err := c.AllExecutions(ctx, ListExecutionsParams{
+		StartDate: FormatTime(from),
+		EndDate:   FormatTime(to),
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := ComputeExecutionStats(executions)
+	stats.ProjectID = projectID
+	stats.From, stats.To = from, to
+
+	byJob := map[int64][]Execution{}
+	for _, execution := range executions {
+		byJob[execution.JobID] = append(byJob[execution.JobID], execution)
+	}
+	for jobID, jobExecutions := range byJob {
+		jobStats := ComputeExecutionStats(jobExecutions)
+		jobStats.JobID = jobID
+		jobStats.ProjectID = projectID
+		jobStats.From, jobStats.To = from, to
+		stats.Jobs = append(stats.Jobs, *jobStats)
+	}
+	sort.Slice(stats.Jobs, func(i, j int) bool {
+		if stats.Jobs[i].Failed != stats.Jobs[j].Failed {
+			return stats.Jobs[i].Failed > stats.Jobs[j].Failed
+		}
+		return stats.Jobs[i].JobID < stats.Jobs[j].JobID
+	})
+	return stats, nil
+}
+
+// ComputeExecutionStats computes statistics from already fetched executions
+// Each run is counted once using its highest ExecutionVersion. Streaks and drift are
+// computed per job in run order.
+func ComputeExecutionStats(executions []Execution) *ExecutionStats {
+	runs := latestExecutionVersions(executions, 0)
+	sort.SliceStable(runs, func(i, j int) bool {
+		return executionRunTime(runs[i]).Before(executionRunTime(runs[j]))
+	})
+
+	stats := &ExecutionStats{CountsByState: map[int64]int{}}
+	var driftTotal time.Duration
+	var driftSamples int
+	previousRun := map[int64]Execution{}
+	streaks := map[int64]int{}
+	for _, run := range runs {
+		stats.Total++
+		stats.CountsByState[run.State]++
+
+		switch run.State {
+		case ExecutionStateSuccess:
+			stats.Succeeded++
+			streaks[run.JobID] = 0
+		case ExecutionStateFailed:
+			stats.Failed++
+			failure := run
+			stats.LastFailure = &failure
+			streaks[run.JobID]++
+			if streaks[run.JobID] > stats.LongestFailureStreak {
+				stats.LongestFailureStreak = streaks[run.JobID]
+			}
+		}
+
+		if previous, ok := previousRun[run.JobID]; ok {
+			if isFlip(previous.State, run.State) {
+				stats.StateFlips++
+			}
+			// The previous run recorded when this one was scheduled
+			scheduled, err := previous.ParsedNextExecutionDatetime()
+			actual, actualErr := run.ParsedLastExecutionDatetime()
+			if err == nil && actualErr == nil && !scheduled.IsZero() && !actual.IsZero() {
+				drift := actual.Sub(scheduled)
+				driftTotal += drift
+				driftSamples++
+				if drift > stats.MaxDrift {
+					stats.MaxDrift = drift
+				}
+			}
+		}
+		if run.State == ExecutionStateSuccess || run.State == ExecutionStateFailed {
+			previousRun[run.JobID] = run
+		}
+	}
+
+	if completed := stats.Succeeded + stats.Failed; completed > 0 {
+		stats.SuccessRatio = float64(stats.Succeeded) / float64(completed)
+	}
+	if driftSamples > 0 {
+		stats.AverageDrift = driftTotal / time.Duration(driftSamples)
+	}
+	if stats.LastFailure != nil {
+		stats.CurrentFailureStreak = streaks[stats.LastFailure.JobID]
+	}
+	return stats
+}
+
+func isFlip(previous, current int64) bool {
+	return (previous == ExecutionStateSuccess && current == ExecutionStateFailed) ||
+		(previous == ExecutionStateFailed && current == ExecutionStateSuccess)
+}
@@ -1,5 +1,12 @@
 package scheduler0_go_client
 
+// Execution states reported in Execution.State
+const (
+	ExecutionStateScheduled int64 = 0
+	ExecutionStateSuccess   int64 = 1
+	ExecutionStateFailed    int64 = 2
+)
+
 type Execution struct {
 	ID                    int64   `json:"id"`
 	AccountID             int64   `json:"accountId"`
@@ -0,0 +1,311 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"time"
+)
+
+// ExecutionEventType describes why an ExecutionEvent was emitted
+type ExecutionEventType string
+
+const (
+	ExecutionEventNew          ExecutionEventType = "new"
+	ExecutionEventStateChanged ExecutionEventType = "state_changed"
+	ExecutionEventError        ExecutionEventType = "error"
+)
+
+// ExecutionEvent is emitted by WatchExecutions for each new or changed execution
+// For ExecutionEventError only Err is set; the watch keeps polling afterwards.
+type ExecutionEvent struct {
+	Type            ExecutionEventType
+	Execution       Execution
+	PreviousState   int64
+	PreviousVersion int64
+	Err             error
+}
+
+// ExecutionCursor records how far a watch has progressed so it can resume after a restart
+type ExecutionCursor struct {
+	Since time.Time                      `json:"since"`
+	Seen  map[string]ExecutionCursorMark `json:"seen"`
+}
+
+// ExecutionCursorMark is the last observed version of an execution, keyed by UniqueID in ExecutionCursor
+type ExecutionCursorMark struct {
+	Version   int64     `json:"version"`
+	State     int64     `json:"state"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ExecutionCursorStore persists the watch cursor between runs
+// LoadCursor returns nil and no error when nothing has been saved yet.
+type ExecutionCursorStore interface {
+	LoadCursor() (*ExecutionCursor, error)
+	SaveCursor(cursor *ExecutionCursor) error
+}
+
+// FileCursorStore stores the watch cursor as JSON in a file
+type FileCursorStore struct {
+	Path string
+}
+
+// LoadCursor reads the cursor from the file
+func (s *FileCursorStore) LoadCursor() (*ExecutionCursor, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor ExecutionCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// SaveCursor writes the cursor to the file, replacing it atomically
+func (s *FileCursorStore) SaveCursor(cursor *ExecutionCursor) error {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.Path, data, 0o600)
+}
+
+type watchConfig struct {
+	store       ExecutionCursorStore
+	minInterval time.Duration
+	maxInterval time.Duration
+	overlap     time.Duration
+	lookback    time.Duration
+}
+
+// WatchOption configures WatchExecutions
+type WatchOption func(*watchConfig)
+
+// WithWatchCursorStore persists the cursor so a restarted watch resumes where it stopped
+func WithWatchCursorStore(store ExecutionCursorStore) WatchOption {
+	return func(cfg *watchConfig) {
+		cfg.store = store
+	}
+}
+
+// WithWatchInterval sets the polling interval; it doubles from min up to max while no events arrive
+func WithWatchInterval(min, max time.Duration) WatchOption {
+	return func(cfg *watchConfig) {
+		cfg.minInterval = min
+		cfg.maxInterval = max
+	}
+}
+
+// WithWatchOverlap sets how far behind the cursor each poll starts, to catch executions that are
+// committed after later ones
+func WithWatchOverlap(overlap time.Duration) WatchOption {
+	return func(cfg *watchConfig) {
+		cfg.overlap = overlap
+	}
+}
+
+// WithWatchLookback sets how long executions that have not succeeded are followed for state
+// changes after they were created
+func WithWatchLookback(lookback time.Duration) WatchOption {
+	return func(cfg *watchConfig) {
+		cfg.lookback = lookback
+	}
+}
+
+// WatchExecutions polls ListExecutions and emits new and state-changed executions in creation order
+// Executions are deduplicated by UniqueID and ExecutionVersion. Executions whose DateCreated can't be
+// parsed are reported as new when first seen. Watching starts at params.StartDate,
+// or now if it is empty, unless a saved cursor is found. params.OrderBy and params.OrderDirection
+// are overridden. The channel is closed when ctx is cancelled.
+func (c *Client) WatchExecutions(ctx context.Context, params ListExecutionsParams, opts ...WatchOption) <-chan ExecutionEvent {
+	cfg := watchConfig{
+		minInterval: 2 * time.Second,
+		maxInterval: time.Minute,
+		overlap:     30 * time.Second,
+		lookback:    time.Hour,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.maxInterval < cfg.minInterval {
+		cfg.maxInterval = cfg.minInterval
+	}
+
+	events := make(chan ExecutionEvent)
+	go c.watchExecutions(ctx, params, cfg, events)
+	return events
+}
+
+func (c *Client) watchExecutions(ctx context.Context, params ListExecutionsParams, cfg watchConfig, events chan<- ExecutionEvent) {
+	defer close(events)
+
+	send := func(event ExecutionEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	var cursor *ExecutionCursor
+	if cfg.store != nil {
+		loaded, err := cfg.store.LoadCursor()
+		if err != nil && !send(ExecutionEvent{Type: ExecutionEventError, Err: err}) {
+			return
+		}
+		cursor = loaded
+	}
+	if cursor == nil {
+		since, err := ParseTime(params.StartDate)
+		if err != nil || since.IsZero() {
+			since = time.Now().UTC()
+		}
+		cursor = &ExecutionCursor{Since: since}
+	}
+	if cursor.Seen == nil {
+		cursor.Seen = map[string]ExecutionCursorMark{}
+	}
+
+	params.OrderBy = "date_created"
+	params.OrderDirection = "asc"
+
+	interval := cfg.minInterval
+	for {
+		emitted, err := c.pollExecutions(ctx, params, cfg, cursor, send)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && !send(ExecutionEvent{Type: ExecutionEventError, Err: err}) {
+			return
+		}
+
+		if emitted > 0 {
+			interval = cfg.minInterval
+		} else if interval *= 2; interval > cfg.maxInterval {
+			interval = cfg.maxInterval
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// pollExecutions fetches executions since the cursor, emits events and advances the cursor
+func (c *Client) pollExecutions(ctx context.Context, params ListExecutionsParams, cfg watchConfig, cursor *ExecutionCursor, send func(ExecutionEvent) bool) (int, error) {
+	params.Offset = 0
+	params.StartDate = FormatTime(watchStart(cursor, cfg.overlap))
+
+	executions, err := c.AllExecutions(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	created := make([]time.Time, len(executions))
+	for i, execution := range executions {
+		created[i], _ = execution.ParsedDateCreated()
+	}
+	order := make([]int, len(executions))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ia, ib := order[a], order[b]
+		if !created[ia].Equal(created[ib]) {
+			return created[ia].Before(created[ib])
+		}
+		return executions[ia].ExecutionVersion < executions[ib].ExecutionVersion
+	})
+
+	emitted := 0
+	changed := false
+	for _, i := range order {
+		execution := executions[i]
+		// An execution without a valid DateCreated can't be placed relative to the cursor, so it
+		// is reported when first seen and remembered as if it was created at the cursor
+		dated := !created[i].IsZero()
+		mark := ExecutionCursorMark{
+			Version:   execution.ExecutionVersion,
+			State:     execution.State,
+			CreatedAt: created[i],
+		}
+		if !dated {
+			mark.CreatedAt = cursor.Since
+		}
+
+		previous, seen := cursor.Seen[execution.UniqueID]
+		var event *ExecutionEvent
+		switch {
+		case !seen && (!dated || !created[i].Before(cursor.Since)):
+			event = &ExecutionEvent{Type: ExecutionEventNew, Execution: execution}
+		case !seen:
+			// Created before the cursor and already reported by an earlier run; track it for state changes
+		case execution.ExecutionVersion < previous.Version:
+			continue
+		case execution.ExecutionVersion > previous.Version || execution.State != previous.State:
+			event = &ExecutionEvent{
+				Type:            ExecutionEventStateChanged,
+				Execution:       execution,
+				PreviousState:   previous.State,
+				PreviousVersion: previous.Version,
+			}
+		default:
+			continue
+		}
+
+		if event != nil {
+			if !send(*event) {
+				break
+			}
+			emitted++
+		}
+
+		cursor.Seen[execution.UniqueID] = mark
+		changed = true
+		if created[i].After(cursor.Since) {
+			cursor.Since = created[i]
+		}
+	}
+
+	horizon := cursor.Since.Add(-cfg.lookback)
+	for id, mark := range cursor.Seen {
+		if mark.CreatedAt.Before(horizon) {
+			delete(cursor.Seen, id)
+			changed = true
+		}
+	}
+
+	if changed && cfg.store != nil {
+		if err := cfg.store.SaveCursor(cursor); err != nil {
+			return emitted, err
+		}
+	}
+	return emitted, nil
+}
+
+// watchStart returns the creation time a poll reads from: the overlap before the cursor, or the
+// creation of the oldest followed execution that has not succeeded, if that is earlier
+func watchStart(cursor *ExecutionCursor, overlap time.Duration) time.Time {
+	start := cursor.Since.Add(-overlap)
+	for _, mark := range cursor.Seen {
+		if mark.State != ExecutionStateSuccess && mark.CreatedAt.Before(start) {
+			start = mark.CreatedAt
+		}
+	}
+	return start
+}
@@ -0,0 +1,329 @@
+package scheduler0_go_client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Executor types accepted in ExecutorRequestBody.Type
+const (
+	ExecutorTypeWebhook       = "webhook_url"
+	ExecutorTypeCloudFunction = "cloud_function"
+	ExecutorTypeContainer     = "container"
+)
+
+// Cloud providers accepted in ExecutorRequestBody.CloudProvider
+const (
+	CloudProviderAWS   = "aws"
+	CloudProviderGCP   = "gcp"
+	CloudProviderAzure = "azure"
+)
+
+// webhookMethods are the HTTP methods a webhook executor may use
+var webhookMethods = []string{"GET", "POST", "PUT", "DELETE"}
+
+// regionPatterns validates region names for each cloud provider
+var regionPatterns = map[string]*regexp.Regexp{
+	CloudProviderAWS:   regexp.MustCompile(`^[a-z]{2}(-gov)?-[a-z]+-\d$`), // us-west-1
+	CloudProviderGCP:   regexp.MustCompile(`^[a-z]+-[a-z]+\d+$`),          // us-central1
+	CloudProviderAzure: regexp.MustCompile(`^[a-z]+\d?$`),                 // westeurope, eastus2
+}
+
+// awsLambdaARN matches arn:aws:lambda:<region>:<account>:function:<name>
+var awsLambdaARN = regexp.MustCompile(`^arn:aws[a-z-]*:lambda:([a-z0-9-]+):\d{12}:function:.+$`)
+
+// TypedExecutor is an executor configuration validated for its executor type
+type TypedExecutor interface {
+	// Type returns the executor type, e.g. ExecutorTypeWebhook
+	Type() string
+	// Validate checks the type-specific fields
+	Validate() error
+	// CreateRequest returns the body for CreateExecutor
+	CreateRequest(createdBy string) *ExecutorRequestBody
+	// UpdateRequest returns the body for UpdateExecutor
+	UpdateRequest(modifiedBy string) *ExecutorUpdateRequestBody
+}
+
+// CloudCredentials are the API credentials of a cloud executor
+type CloudCredentials struct {
+	APIKey    string
+	APISecret string
+}
+
+// WebhookExecutor configures an executor that calls an HTTP endpoint
+type WebhookExecutor struct {
+	Name   string
+	URL    string
+	Method string
+	Secret string
+}
+
+// NewWebhookExecutor creates and validates a webhook executor configuration
+func NewWebhookExecutor(name, webhookURL, method, secret string) (*WebhookExecutor, error) {
+	executor := &WebhookExecutor{Name: name, URL: webhookURL, Method: strings.ToUpper(method), Secret: secret}
+	if err := executor.Validate(); err != nil {
+		return nil, err
+	}
+	return executor, nil
+}
+
+// Type returns ExecutorTypeWebhook
+func (e *WebhookExecutor) Type() string { return ExecutorTypeWebhook }
+
+// Validate checks the URL scheme, HTTP method and secret
+func (e *WebhookExecutor) Validate() error {
+	var errs []error
+	if e.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if err := validateURL(e.URL, "http", "https"); err != nil {
+		errs = append(errs, fmt.Errorf("webhook URL: %w", err))
+	}
+	if !containsString(webhookMethods, e.Method) {
+		errs = append(errs, fmt.Errorf("webhook method must be one of %s, got %q", strings.Join(webhookMethods, ", "), e.Method))
+	}
+	if e.Secret == "" {
+		errs = append(errs, errors.New("webhook secret is required"))
+	}
+	return errors.Join(errs...)
+}
+
+// CreateRequest returns the body for CreateExecutor
+func (e *WebhookExecutor) CreateRequest(createdBy string) *ExecutorRequestBody {
+	return &ExecutorRequestBody{
+		Name:          e.Name,
+		Type:          ExecutorTypeWebhook,
+		WebhookURL:    e.URL,
+		WebhookMethod: e.Method,
+		WebhookSecret: e.Secret,
+		CreatedBy:     createdBy,
+	}
+}
+
+// UpdateRequest returns the body for UpdateExecutor
+func (e *WebhookExecutor) UpdateRequest(modifiedBy string) *ExecutorUpdateRequestBody {
+	return &ExecutorUpdateRequestBody{
+		Name:          e.Name,
+		Type:          ExecutorTypeWebhook,
+		WebhookURL:    e.URL,
+		WebhookMethod: e.Method,
+		WebhookSecret: e.Secret,
+		ModifiedBy:    modifiedBy,
+	}
+}
+
+// CloudFunctionExecutor configures an executor that invokes a serverless function
+type CloudFunctionExecutor struct {
+	Name          string
+	CloudProvider string
+	Region        string
+	ResourceURL   string // HTTPS URL of the function, or a Lambda ARN for AWS
+	Credentials   CloudCredentials
+}
+
+// NewCloudFunctionExecutor creates and validates a cloud function executor configuration
+func NewCloudFunctionExecutor(name, provider, region, resourceURL string, credentials CloudCredentials) (*CloudFunctionExecutor, error) {
+	executor := &CloudFunctionExecutor{
+		Name:          name,
+		CloudProvider: strings.ToLower(provider),
+		Region:        region,
+		ResourceURL:   resourceURL,
+		Credentials:   credentials,
+	}
+	if err := executor.Validate(); err != nil {
+		return nil, err
+	}
+	return executor, nil
+}
+
+// Type returns ExecutorTypeCloudFunction
+func (e *CloudFunctionExecutor) Type() string { return ExecutorTypeCloudFunction }
+
+// Validate checks the provider and region combination, resource URL and credentials
+func (e *CloudFunctionExecutor) Validate() error {
+	errs := validateCloudExecutor(e.Name, e.CloudProvider, e.Region, e.Credentials)
+
+	if match := awsLambdaARN.FindStringSubmatch(e.ResourceURL); match != nil {
+		if e.CloudProvider != CloudProviderAWS {
+			errs = append(errs, fmt.Errorf("lambda ARN requires provider %q, got %q", CloudProviderAWS, e.CloudProvider))
+		} else if match[1] != e.Region {
+			errs = append(errs, fmt.Errorf("lambda ARN region %q does not match region %q", match[1], e.Region))
+		}
+	} else if err := validateURL(e.ResourceURL, "https"); err != nil {
+		errs = append(errs, fmt.Errorf("resource URL: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// CreateRequest returns the body for CreateExecutor
+func (e *CloudFunctionExecutor) CreateRequest(createdBy string) *ExecutorRequestBody {
+	return &ExecutorRequestBody{
+		Name:             e.Name,
+		Type:             ExecutorTypeCloudFunction,
+		Region:           e.Region,
+		CloudProvider:    e.CloudProvider,
+		CloudResourceURL: e.ResourceURL,
+		CloudAPIKey:      e.Credentials.APIKey,
+		CloudAPISecret:   e.Credentials.APISecret,
+		CreatedBy:        createdBy,
+	}
+}
+
+// UpdateRequest returns the body for UpdateExecutor
+func (e *CloudFunctionExecutor) UpdateRequest(modifiedBy string) *ExecutorUpdateRequestBody {
+	return &ExecutorUpdateRequestBody{
+		Name:             e.Name,
+		Type:             ExecutorTypeCloudFunction,
+		Region:           e.Region,
+		CloudProvider:    e.CloudProvider,
+		CloudResourceURL: e.ResourceURL,
+		CloudAPIKey:      e.Credentials.APIKey,
+		CloudAPISecret:   e.Credentials.APISecret,
+		ModifiedBy:       modifiedBy,
+	}
+}
+
+// ContainerExecutor configures an executor that runs a container image
+type ContainerExecutor struct {
+	Name          string
+	CloudProvider string
+	Region        string
+	ImageURL      string // Sent as CloudResourceURL
+	Credentials   CloudCredentials
+}
+
+// NewContainerExecutor creates and validates a container executor configuration
+func NewContainerExecutor(name, provider, region, imageURL string, credentials CloudCredentials) (*ContainerExecutor, error) {
+	executor := &ContainerExecutor{
+		Name:          name,
+		CloudProvider: strings.ToLower(provider),
+		Region:        region,
+		ImageURL:      imageURL,
+		Credentials:   credentials,
+	}
+	if err := executor.Validate(); err != nil {
+		return nil, err
+	}
+	return executor, nil
+}
+
+// Type returns ExecutorTypeContainer
+func (e *ContainerExecutor) Type() string { return ExecutorTypeContainer }
+
+// Validate checks the provider and region combination, image and credentials
+func (e *ContainerExecutor) Validate() error {
+	errs := validateCloudExecutor(e.Name, e.CloudProvider, e.Region, e.Credentials)
+	if e.ImageURL == "" {
+		errs = append(errs, errors.New("image URL is required"))
+	} else if strings.ContainsAny(e.ImageURL, " \t\n") {
+		errs = append(errs, fmt.Errorf("image URL %q must not contain whitespace", e.ImageURL))
+	}
+	return errors.Join(errs...)
+}
+
+// CreateRequest returns the body for CreateExecutor
+func (e *ContainerExecutor) CreateRequest(createdBy string) *ExecutorRequestBody {
+	return &ExecutorRequestBody{
+		Name:             e.Name,
+		Type:             ExecutorTypeContainer,
+		Region:           e.Region,
+		CloudProvider:    e.CloudProvider,
+		CloudResourceURL: e.ImageURL,
+		CloudAPIKey:      e.Credentials.APIKey,
+		CloudAPISecret:   e.Credentials.APISecret,
+		CreatedBy:        createdBy,
+	}
+}
+
+// UpdateRequest returns the body for UpdateExecutor
+func (e *ContainerExecutor) UpdateRequest(modifiedBy string) *ExecutorUpdateRequestBody {
+	return &ExecutorUpdateRequestBody{
+		Name:             e.Name,
+		Type:             ExecutorTypeContainer,
+		Region:           e.Region,
+		CloudProvider:    e.CloudProvider,
+		CloudResourceURL: e.ImageURL,
+		CloudAPIKey:      e.Credentials.APIKey,
+		CloudAPISecret:   e.Credentials.APISecret,
+		ModifiedBy:       modifiedBy,
+	}
+}
+
+// DecodeExecutor converts an Executor returned by the API into its typed configuration
+// The result is not validated, since stored executors may predate the current rules.
+func DecodeExecutor(executor Executor) (TypedExecutor, error) {
+	credentials := CloudCredentials{APIKey: executor.CloudAPIKey, APISecret: executor.CloudAPISecret}
+	switch executor.Type {
+	case ExecutorTypeWebhook:
+		return &WebhookExecutor{
+			Name:   executor.Name,
+			URL:    executor.WebhookURL,
+			Method: executor.WebhookMethod,
+			Secret: executor.WebhookSecret,
+		}, nil
+	case ExecutorTypeCloudFunction:
+		return &CloudFunctionExecutor{
+			Name:          executor.Name,
+			CloudProvider: executor.CloudProvider,
+			Region:        executor.Region,
+			ResourceURL:   executor.CloudResourceURL,
+			Credentials:   credentials,
+		}, nil
+	case ExecutorTypeContainer:
+		return &ContainerExecutor{
+			Name:          executor.Name,
+			CloudProvider: executor.CloudProvider,
+			Region:        executor.Region,
+			ImageURL:      executor.CloudResourceURL,
+			Credentials:   credentials,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown executor type %q", executor.Type)
+	}
+}
+
+func validateCloudExecutor(name, provider, region string, credentials CloudCredentials) []error {
+	var errs []error
+	if name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	pattern, ok := regionPatterns[provider]
+	if !ok {
+		errs = append(errs, fmt.Errorf("cloud provider must be one of %s, %s or %s, got %q", CloudProviderAWS, CloudProviderGCP, CloudProviderAzure, provider))
+	} else if !pattern.MatchString(region) {
+		errs = append(errs, fmt.Errorf("region %q is not a valid %s region", region, provider))
+	}
+	if credentials.APIKey == "" || credentials.APISecret == "" {
+		errs = append(errs, errors.New("cloud API key and secret are required"))
+	}
+	return errs
+}
+
+func validateURL(raw string, schemes ...string) error {
+	if raw == "" {
+		return errors.New("is required")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if !containsString(schemes, u.Scheme) {
+		return fmt.Errorf("scheme must be %s, got %q", strings.Join(schemes, " or "), u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("host is required")
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package scheduler0_go_client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WebhookDryRunHeader marks self-test requests so receivers can skip real work
+const WebhookDryRunHeader = "X-Scheduler0-Dry-Run"
+
+// ExecutorTLSInfo describes the TLS connection to a webhook target
+type ExecutorTLSInfo struct {
+	Version     string
+	CipherSuite string
+	Subject     string
+	Issuer      string
+	NotAfter    time.Time
+}
+
+// ExecutorTestReport is the diagnostic result of TestExecutor
+type ExecutorTestReport struct {
+	ExecutorID int64
+	URL        string
+	Method     string
+	StatusCode int
+	Latency    time.Duration
+	TLS        *ExecutorTLSInfo // nil for plain HTTP targets or failed connections
+	Success    bool             // The target answered 2xx without any problems
+	Problems   []string
+}
+
+type executorTestConfig struct {
+	timeout       time.Duration
+	maxLatency    time.Duration
+	certExpiryMin time.Duration
+}
+
+// ExecutorTestOption configures TestExecutor
+type ExecutorTestOption func(*executorTestConfig)
+
+// WithExecutorTestTimeout bounds the dry-run request (defaults to 10 seconds)
+func WithExecutorTestTimeout(timeout time.Duration) ExecutorTestOption {
+	return func(cfg *executorTestConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithExecutorTestMaxLatency reports responses slower than latency as a problem (defaults to 2 seconds)
+func WithExecutorTestMaxLatency(latency time.Duration) ExecutorTestOption {
+	return func(cfg *executorTestConfig) {
+		cfg.maxLatency = latency
+	}
+}
+
+// WithExecutorTestCertExpiry reports certificates expiring within d as a problem (defaults to 14 days)
+func WithExecutorTestCertExpiry(d time.Duration) ExecutorTestOption {
+	return func(cfg *executorTestConfig) {
+		cfg.certExpiryMin = d
+	}
+}
+
+// TestExecutor sends a signed dry-run request to a webhook executor's WebhookURL
+// The request uses the executor's WebhookMethod and carries WebhookDryRunHeader. It is signed
+// with the executor's WebhookSecret through SignWebhookDryRun, not SignWebhookPayload, so a
+// receiver that only checks delivery signatures rejects it with 401; the webhook package's
+// Handler verifies it and answers without dispatching. Connection failures, non-2xx responses, slow
+// responses and TLS issues are reported in the returned report; an error is only returned
+// when the executor cannot be tested at all.
+func (c *Client) TestExecutor(ctx context.Context, executor Executor, opts ...ExecutorTestOption) (*ExecutorTestReport, error) {
+	cfg := executorTestConfig{
+		timeout:       10 * time.Second,
+		maxLatency:    2 * time.Second,
+		certExpiryMin: 14 * 24 * time.Hour,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if executor.Type != ExecutorTypeWebhook {
+		return nil, fmt.Errorf("executor %d has type %q, only %q executors can be tested", executor.ID, executor.Type, ExecutorTypeWebhook)
+	}
+	method := strings.ToUpper(executor.WebhookMethod)
+	if method == "" {
+		method = "POST"
+	}
+	if err := validateURL(executor.WebhookURL, "http", "https"); err != nil {
+		return nil, fmt.Errorf("webhook URL: %w", err)
+	}
+
+	report := &ExecutorTestReport{ExecutorID: executor.ID, URL: executor.WebhookURL, Method: method}
+
+	req, err := newDryRunRequest(ctx, executor, method)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	report.Latency = time.Since(start)
+	if err != nil {
+		report.Problems = append(report.Problems, fmt.Sprintf("request failed: %v", err))
+		return report, nil
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	report.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		report.Problems = append(report.Problems, fmt.Sprintf("target responded %s", resp.Status))
+	}
+	if report.Latency > cfg.maxLatency {
+		report.Problems = append(report.Problems, fmt.Sprintf("latency %s exceeds %s", report.Latency.Round(time.Millisecond), cfg.maxLatency))
+	}
+
+	if resp.TLS == nil {
+		report.Problems = append(report.Problems, "webhook URL does not use TLS")
+	} else {
+		report.TLS = tlsInfo(resp.TLS)
+		if report.TLS.Version != "TLS 1.2" && report.TLS.Version != "TLS 1.3" {
+			report.Problems = append(report.Problems, fmt.Sprintf("outdated TLS version %s", report.TLS.Version))
+		}
+		if !report.TLS.NotAfter.IsZero() && time.Until(report.TLS.NotAfter) < cfg.certExpiryMin {
+			report.Problems = append(report.Problems, fmt.Sprintf("certificate expires %s", report.TLS.NotAfter.Format(time.RFC3339)))
+		}
+	}
+
+	report.Success = len(report.Problems) == 0
+	return report, nil
+}
+
+// newDryRunRequest builds a signed request carrying a placeholder job for the executor
+func newDryRunRequest(ctx context.Context, executor Executor, method string) (*http.Request, error) {
+	var body []byte
+	if method != "GET" {
+		executorID := executor.ID
+		jobs := []Job{{
+			AccountID:  executor.AccountID,
+			ExecutorID: &executorID,
+			Data:       `{"dryRun":true}`,
+		}}
+		var err error
+		body, err = json.Marshal(jobs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, executor.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	nonceBytes := make([]byte, 16)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return nil, err
+	}
+	nonce := hex.EncodeToString(nonceBytes)
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookDryRunHeader, "true")
+	req.Header.Set(WebhookTimestampHeader, fmt.Sprintf("%d", timestamp))
+	req.Header.Set(WebhookNonceHeader, nonce)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookDryRun(executor.WebhookSecret, timestamp, nonce, body))
+	return req, nil
+}
+
+func tlsInfo(state *tls.ConnectionState) *ExecutorTLSInfo {
+	info := &ExecutorTLSInfo{
+		Version:     tls.VersionName(state.Version),
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+	}
+	if len(state.PeerCertificates) > 0 {
+		leaf := state.PeerCertificates[0]
+		info.Subject = leaf.Subject.String()
+		info.Issuer = leaf.Issuer.String()
+		info.NotAfter = leaf.NotAfter
+	}
+	return info
+}
@@ -1,8 +1,8 @@
 package scheduler0_go_client
 
 // CreateExecutor creates a new executor
-func (c *Client) CreateExecutor(body *ExecutorRequestBody) (*ExecutorResponse, error) {
-	req, err := c.newRequest("POST", "/executors", body)
+func (c *Client) CreateExecutor(body *ExecutorRequestBody, opts ...RequestOption) (*ExecutorResponse, error) {
+	req, err := c.newRequest("POST", "/executors", body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1,14 +1,20 @@
 package scheduler0_go_client
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // DeleteExecutor deletes an executor by ID
-func (c *Client) DeleteExecutor(id string, body *ExecutorDeleteRequestBody) error {
-	req, err := c.newRequest("DELETE", fmt.Sprintf("/executors/%s", id), body)
+func (c *Client) DeleteExecutor(id string, body *ExecutorDeleteRequestBody, opts ...RequestOption) error {
+	return c.deleteExecutor(context.Background(), id, body, opts...)
+}
+
+func (c *Client) deleteExecutor(ctx context.Context, id string, body *ExecutorDeleteRequestBody, opts ...RequestOption) error {
+	req, err := c.newRequest("DELETE", fmt.Sprintf("/executors/%s", id), body, opts...)
 	if err != nil {
 		return err
 	}
 
-	return c.do(req, nil)
+	return c.doContext(ctx, req, nil)
 }
-
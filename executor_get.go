@@ -3,8 +3,8 @@ package scheduler0_go_client
 import "fmt"
 
 // GetExecutor retrieves a single executor by ID
-func (c *Client) GetExecutor(id string) (*ExecutorResponse, error) {
-	req, err := c.newRequest("GET", fmt.Sprintf("/executors/%s", id), nil)
+func (c *Client) GetExecutor(id string, opts ...RequestOption) (*ExecutorResponse, error) {
+	req, err := c.newRequest("GET", fmt.Sprintf("/executors/%s", id), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,263 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MigrateExecutorOptions configures MigrateExecutor and RollbackExecutorMigration
+type MigrateExecutorOptions struct {
+	Jobs         ListJobsParams // Scope of the job scan, e.g. a single project (defaults to all jobs of the account)
+	Concurrency  int            // Parallel UpdateJob calls (defaults to 4)
+	RateLimit    float64        // Maximum UpdateJob calls per second (0 for unlimited)
+	RollbackPath string         // File the original executor IDs are written to before any job is updated (required)
+	ModifiedBy   string
+	// DeleteOld deletes the old executor once no job of the account references it any more
+	DeleteOld bool
+	DeletedBy string
+}
+
+// ExecutorRollbackEntry is the original executor of a migrated job
+type ExecutorRollbackEntry struct {
+	JobID      int64  `json:"jobId"`
+	AccountID  int64  `json:"accountId"`
+	ExecutorID *int64 `json:"executorId"`
+}
+
+// ExecutorRollback is the content of a rollback file written by MigrateExecutor
+type ExecutorRollback struct {
+	FromExecutorID int64                   `json:"fromExecutorId"`
+	ToExecutorID   int64                   `json:"toExecutorId"`
+	CreatedAt      time.Time               `json:"createdAt"`
+	Jobs           []ExecutorRollbackEntry `json:"jobs"`
+}
+
+// JobUpdateFailure records a job that could not be updated
+type JobUpdateFailure struct {
+	JobID int64
+	Err   error
+}
+
+// ExecutorMigrationResult summarises a migration or rollback
+type ExecutorMigrationResult struct {
+	Matched         int // Jobs that referenced the old executor
+	Updated         int
+	Failed          []JobUpdateFailure
+	RollbackPath    string
+	Remaining       int // Jobs still referencing the old executor, only counted with DeleteOld
+	ExecutorDeleted bool
+}
+
+// MigrateExecutor re-points every job using executor fromID to executor toID
+// Matching jobs are found with the job iterator and their original executor IDs are written to
+// opts.RollbackPath before any update is made, so RollbackExecutorMigration can undo the run. A
+// rollback file left by an earlier run of the same migration is extended rather than replaced, so
+// rerunning after a partial failure keeps the original executors of jobs moved by the first run. Each
+// job is fetched again and sent back whole with the new executor, since an update replaces the job.
+// Updates run with opts.Concurrency workers and at most opts.RateLimit calls per second. Failed
+// updates are collected in the result rather than aborting the migration; the old executor is only
+// deleted when every update succeeded and no job of the account references it any more.
+func (c *Client) MigrateExecutor(ctx context.Context, fromID, toID int64, opts MigrateExecutorOptions) (*ExecutorMigrationResult, error) {
+	if fromID == toID {
+		return nil, errors.New("source and target executor must differ")
+	}
+	if opts.RollbackPath == "" {
+		return nil, errors.New("rollback path is required")
+	}
+
+	jobs, err := c.SearchJobs(ctx, opts.Jobs, JobExecutorIs(fromID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	rollback := ExecutorRollback{
+		FromExecutorID: fromID,
+		ToExecutorID:   toID,
+		CreatedAt:      time.Now().UTC(),
+		Jobs:           make([]ExecutorRollbackEntry, 0, len(jobs)),
+	}
+	for _, job := range jobs {
+		rollback.Jobs = append(rollback.Jobs, ExecutorRollbackEntry{JobID: job.ID, AccountID: job.AccountID, ExecutorID: job.ExecutorID})
+	}
+	updates := make([]ExecutorRollbackEntry, len(rollback.Jobs))
+	for i, entry := range rollback.Jobs {
+		target := toID
+		updates[i] = ExecutorRollbackEntry{JobID: entry.JobID, AccountID: entry.AccountID, ExecutorID: &target}
+	}
+
+	if rollback, err = mergeExecutorRollback(opts.RollbackPath, rollback); err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(rollback, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(opts.RollbackPath, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write rollback file: %w", err)
+	}
+
+	result := &ExecutorMigrationResult{Matched: len(jobs), RollbackPath: opts.RollbackPath}
+	if err := c.repointJobs(ctx, updates, opts, result); err != nil {
+		return result, err
+	}
+
+	if !opts.DeleteOld || len(result.Failed) > 0 {
+		return result, nil
+	}
+
+	// Check the whole account, not just opts.Jobs, since other projects may still use the executor
+	remaining, err := c.SearchJobs(ctx, ListJobsParams{AccountID: opts.Jobs.AccountID}, JobExecutorIs(fromID))
+	if err != nil {
+		return result, fmt.Errorf("failed to check remaining jobs: %w", err)
+	}
+	result.Remaining = len(remaining)
+	if result.Remaining > 0 {
+		return result, nil
+	}
+
+	body := &ExecutorDeleteRequestBody{AccountID: opts.Jobs.AccountID, DeletedBy: opts.DeletedBy}
+	if err := c.deleteExecutor(ctx, strconv.FormatInt(fromID, 10), body); err != nil {
+		return result, fmt.Errorf("failed to delete executor %d: %w", fromID, err)
+	}
+	result.ExecutorDeleted = true
+	return result, nil
+}
+
+// RollbackExecutorMigration restores the executor IDs recorded in a rollback file
+// The original executor must still exist, so it cannot undo a migration that used DeleteOld.
+func (c *Client) RollbackExecutorMigration(ctx context.Context, path string, opts MigrateExecutorOptions) (*ExecutorMigrationResult, error) {
+	rollback, err := readExecutorRollback(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExecutorMigrationResult{Matched: len(rollback.Jobs), RollbackPath: path}
+	return result, c.repointJobs(ctx, rollback.Jobs, opts, result)
+}
+
+// readExecutorRollback reads a rollback file written by MigrateExecutor
+func readExecutorRollback(path string) (*ExecutorRollback, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rollback ExecutorRollback
+	if err := json.Unmarshal(data, &rollback); err != nil {
+		return nil, fmt.Errorf("invalid rollback file %s: %w", path, err)
+	}
+	return &rollback, nil
+}
+
+// mergeExecutorRollback adds the entries of rollback to the rollback file at path, if there is one
+// Entries already in the file win, since they hold the executor a job had before the first run.
+// A file recording a different migration is an error rather than being overwritten.
+func mergeExecutorRollback(path string, rollback ExecutorRollback) (ExecutorRollback, error) {
+	existing, err := readExecutorRollback(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return rollback, nil
+	}
+	if err != nil {
+		return rollback, err
+	}
+	if existing.FromExecutorID != rollback.FromExecutorID || existing.ToExecutorID != rollback.ToExecutorID {
+		return rollback, fmt.Errorf("rollback file %s records a migration from executor %d to %d",
+			path, existing.FromExecutorID, existing.ToExecutorID)
+	}
+
+	recorded := map[int64]bool{}
+	for _, entry := range existing.Jobs {
+		recorded[entry.JobID] = true
+	}
+	for _, entry := range rollback.Jobs {
+		if !recorded[entry.JobID] {
+			existing.Jobs = append(existing.Jobs, entry)
+		}
+	}
+	return *existing, nil
+}
+
+// repointJob fetches the job and puts it back with entry.ExecutorID
+// The whole job is sent since a PUT replaces it; fetching it right before the update keeps
+// changes made since the migration scanned the jobs.
+func (c *Client) repointJob(ctx context.Context, entry ExecutorRollbackEntry, modifiedBy string) error {
+	id := strconv.FormatInt(entry.JobID, 10)
+	account := accountOverride(entry.AccountID)
+	current, err := c.getJob(ctx, id, account)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+
+	job := current.Data
+	job.ExecutorID = entry.ExecutorID
+	_, err = c.updateJob(ctx, id, jobUpdateBody(job, modifiedBy), account)
+	return err
+}
+
+// repointJobs sets each job's executor to entry.ExecutorID, recording outcomes in result
+// It returns ctx.Err() if the context is cancelled before every job was attempted.
+func (c *Client) repointJobs(ctx context.Context, entries []ExecutorRollbackEntry, opts MigrateExecutorOptions, result *ExecutorMigrationResult) error {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	var tick <-chan time.Time
+	if opts.RateLimit > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.RateLimit))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	work := make(chan ExecutorRollbackEntry)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entry := range work {
+				err := c.repointJob(ctx, entry, opts.ModifiedBy)
+
+				mu.Lock()
+				if err != nil {
+					result.Failed = append(result.Failed, JobUpdateFailure{JobID: entry.JobID, Err: err})
+				} else {
+					result.Updated++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var err error
+dispatch:
+	for i, entry := range entries {
+		if tick != nil && i > 0 {
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+				break dispatch
+			case <-tick:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			break dispatch
+		case work <- entry:
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	sort.Slice(result.Failed, func(i, j int) bool { return result.Failed[i].JobID < result.Failed[j].JobID })
+	return err
+}
@@ -3,8 +3,8 @@ package scheduler0_go_client
 import "fmt"
 
 // UpdateExecutor updates an existing executor
-func (c *Client) UpdateExecutor(id string, body *ExecutorUpdateRequestBody) (*ExecutorResponse, error) {
-	req, err := c.newRequest("PUT", fmt.Sprintf("/executors/%s", id), body)
+func (c *Client) UpdateExecutor(id string, body *ExecutorUpdateRequestBody, opts ...RequestOption) (*ExecutorResponse, error) {
+	req, err := c.newRequest("PUT", fmt.Sprintf("/executors/%s", id), body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1,8 +1,8 @@
 package scheduler0_go_client
 
 // ListFeatures retrieves all available features
-func (c *Client) ListFeatures() (*FeaturesResponse, error) {
-	req, err := c.newRequest("GET", "/features", nil)
+func (c *Client) ListFeatures(opts ...RequestOption) (*FeaturesResponse, error) {
+	req, err := c.newRequest("GET", "/features", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4,10 +4,13 @@ go 1.23
 
 // Version v1.1.3 - Added audit fields (createdBy, modifiedBy, deletedBy) to all request bodies
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/robfig/cron/v3 v3.0.1
+	github.com/stretchr/testify v1.10.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
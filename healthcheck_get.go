@@ -8,7 +8,7 @@ import (
 )
 
 // Healthcheck retrieves the current leader and raft stats (no authentication required)
-func (c *Client) Healthcheck() (*HealthcheckResponse, error) {
+func (c *Client) Healthcheck(opts ...RequestOption) (*HealthcheckResponse, error) {
 	// Create a request without authentication for healthcheck
 	versionPrefix := fmt.Sprintf("/api/%s/", c.Version)
 	rel := &url.URL{Path: path.Join(fmt.Sprintf("%s%s", c.BaseURL.Path, versionPrefix), "healthcheck")}
@@ -19,6 +19,7 @@ func (c *Client) Healthcheck() (*HealthcheckResponse, error) {
 		return nil, err
 	}
 
+	req = collectRequestOptions(opts).apply(req, false)
 	req.Header.Set("Content-Type", "application/json")
 
 	var result HealthcheckResponse
@@ -1,31 +1,71 @@
 package scheduler0_go_client
 
+import (
+	"context"
+	"fmt"
+)
+
 // CreateJob creates a new job
 // Note: This is a convenience method that wraps a single job in an array.
 // The API always expects an array and returns 202 Accepted with a request ID for async tracking.
 // For better control, use BatchCreateJobs directly.
-// accountIDOverride is optional - if provided, overrides the client's default account ID
-func (c *Client) CreateJob(body *JobRequestBody, accountIDOverride ...string) (*BatchJobResponse, error) {
-	return c.BatchCreateJobs([]JobRequestBody{*body}, accountIDOverride...)
+// Pass WithAccount to override the client's default account ID
+func (c *Client) CreateJob(body *JobRequestBody, opts ...RequestOption) (*BatchJobResponse, error) {
+	return c.BatchCreateJobs([]JobRequestBody{*body}, opts...)
 }
 
 // BatchCreateJobs creates multiple jobs in a single request
-// accountIDOverride is optional - if provided, overrides the client's default account ID
-func (c *Client) BatchCreateJobs(jobs []JobRequestBody, accountIDOverride ...string) (*BatchJobResponse, error) {
-	var accountID string
-	if len(accountIDOverride) > 0 {
-		accountID = accountIDOverride[0]
+// Pass WithAccount to override the client's default account ID
+func (c *Client) BatchCreateJobs(jobs []JobRequestBody, opts ...RequestOption) (*BatchJobResponse, error) {
+	return c.batchCreateJobs(context.Background(), jobs, opts...)
+}
+
+// BatchCreateJobsPerAccount splits jobs by AccountID and creates each group in its own request
+// Jobs without an AccountID are sent with opts, i.e. to the WithAccount override or the client
+// default. A WithAccount naming a different account than a group's jobs returns ErrAccountMismatch
+// before any group is sent. Results are keyed by account ID, 0 for jobs without one. Creation stops
+// at the first group that fails, or once ctx is done; the groups created before it are returned
+// with the error.
+func (c *Client) BatchCreateJobsPerAccount(ctx context.Context, jobs []JobRequestBody, opts ...RequestOption) (map[int64]*BatchJobResponse, error) {
+	var order []int64
+	groups := map[int64][]JobRequestBody{}
+	for _, job := range jobs {
+		if _, ok := groups[job.AccountID]; !ok {
+			order = append(order, job.AccountID)
+		}
+		groups[job.AccountID] = append(groups[job.AccountID], job)
+	}
+
+	groupOpts := map[int64][]RequestOption{}
+	for _, accountID := range order {
+		options, err := listAccountOptions(accountID, opts)
+		if err != nil {
+			return nil, err
+		}
+		groupOpts[accountID] = options
 	}
-	req, err := c.newRequest("POST", "/jobs", jobs, accountID)
+
+	results := map[int64]*BatchJobResponse{}
+	for _, accountID := range order {
+		result, err := c.batchCreateJobs(ctx, groups[accountID], groupOpts[accountID]...)
+		if err != nil {
+			return results, fmt.Errorf("failed to create jobs for account %d: %w", accountID, err)
+		}
+		results[accountID] = result
+	}
+	return results, nil
+}
+
+func (c *Client) batchCreateJobs(ctx context.Context, jobs []JobRequestBody, opts ...RequestOption) (*BatchJobResponse, error) {
+	req, err := c.newRequest("POST", "/jobs", jobs, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var result BatchJobResponse
-	err = c.do(req, &result)
+	err = c.doContext(ctx, req, &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
-
@@ -3,13 +3,9 @@ package scheduler0_go_client
 import "fmt"
 
 // DeleteJob deletes a job by ID
-// accountIDOverride is optional - if provided, overrides the client's default account ID
-func (c *Client) DeleteJob(id string, body *JobDeleteRequestBody, accountIDOverride ...string) error {
-	var accountID string
-	if len(accountIDOverride) > 0 {
-		accountID = accountIDOverride[0]
-	}
-	req, err := c.newRequest("DELETE", fmt.Sprintf("/jobs/%s", id), body, accountID)
+// Pass WithAccount to override the client's default account ID
+func (c *Client) DeleteJob(id string, body *JobDeleteRequestBody, opts ...RequestOption) error {
+	req, err := c.newRequest("DELETE", fmt.Sprintf("/jobs/%s", id), body, opts...)
 	if err != nil {
 		return err
 	}
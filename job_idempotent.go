@@ -0,0 +1,211 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JobFingerprintKey is the key CreateJobsIdempotent adds to job data holding a JSON object
+const JobFingerprintKey = "_scheduler0Fingerprint"
+
+// IdempotentCreateOptions configures CreateJobsIdempotent
+type IdempotentCreateOptions struct {
+	// IdempotencyKey is sent in the Idempotency-Key header (defaults to a key derived from the
+	// fingerprints of the jobs sent, so a retry sending the same jobs reuses it while a rerun
+	// sending only the missing jobs gets a new one)
+	IdempotencyKey string
+	// Jobs is the scope of the scan for previously created jobs (defaults to all jobs of the
+	// batch's account)
+	Jobs ListJobsParams
+}
+
+// IdempotentCreateResult summarises CreateJobsIdempotent
+type IdempotentCreateResult struct {
+	IdempotencyKey string            // Empty if no job had to be created and no key was given
+	Response       *BatchJobResponse // Nil if no job had to be created
+	Created        []JobRequestBody  // Jobs sent, with their fingerprints embedded
+	Skipped        []Job             // Existing jobs that matched jobs of the batch
+	// Changed are existing jobs created from a job of the batch but modified since; the batch job
+	// was created again
+	Changed []Job
+}
+
+// JobFingerprint returns a deterministic fingerprint of every field the job is created with
+// A fingerprint embedded in the data under JobFingerprintKey is ignored, so the result is the same
+// before and after WithJobFingerprint.
+func JobFingerprint(job JobRequestBody) string {
+	executor := ""
+	if job.ExecutorID != nil {
+		executor = strconv.FormatInt(*job.ExecutorID, 10)
+	}
+	status := job.Status
+	if status == "" {
+		// Jobs created without a status are active
+		status = "active"
+	}
+	fields := []string{
+		strconv.FormatInt(job.ProjectID, 10), executor, job.Spec, job.StartDate, job.EndDate, job.Timezone,
+		strconv.FormatInt(job.TimezoneOffset, 10), strconv.Itoa(job.RetryMax), status, job.CreatedBy,
+		canonicalJobData(job.Data),
+	}
+
+	sum := sha256.New()
+	for _, field := range fields {
+		// Length prefixes keep adjacent fields from running into each other
+		fmt.Fprintf(sum, "%d:%s;", len(field), field)
+	}
+	return hex.EncodeToString(sum.Sum(nil))[:32]
+}
+
+// WithJobFingerprint returns job with its fingerprint embedded in the data
+// Only data holding a JSON object can carry a fingerprint; other jobs are returned unchanged and
+// are recognised by their content alone.
+func WithJobFingerprint(job JobRequestBody) JobRequestBody {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(job.Data), &object); err != nil || object == nil {
+		return job
+	}
+	fingerprint, _ := json.Marshal(JobFingerprint(job))
+	object[JobFingerprintKey] = fingerprint
+	data, err := json.Marshal(object)
+	if err != nil {
+		return job
+	}
+	job.Data = string(data)
+	return job
+}
+
+// ExistingJobFingerprint returns the fingerprint of a job's current fields
+// A fingerprint embedded in the data is not trusted, since the job may have been updated after it
+// was created.
+func ExistingJobFingerprint(job Job) string {
+	return JobFingerprint(JobRequestBody{
+		ProjectID:      job.ProjectID,
+		Timezone:       job.Timezone,
+		ExecutorID:     job.ExecutorID,
+		Data:           job.Data,
+		Spec:           job.Spec,
+		StartDate:      job.StartDate,
+		EndDate:        job.EndDate,
+		TimezoneOffset: job.TimezoneOffset,
+		RetryMax:       job.RetryMax,
+		Status:         job.Status,
+		CreatedBy:      job.CreatedBy,
+	})
+}
+
+// embeddedJobFingerprint returns the fingerprint WithJobFingerprint embedded in a job's data, if any
+func embeddedJobFingerprint(job Job) string {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(job.Data), &object); err != nil {
+		return ""
+	}
+	var fingerprint string
+	json.Unmarshal(object[JobFingerprintKey], &fingerprint)
+	return fingerprint
+}
+
+// canonicalJobData drops an embedded fingerprint and normalises key order and whitespace of JSON
+// object data
+func canonicalJobData(data string) string {
+	var object map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil || object == nil || decoder.More() {
+		return data
+	}
+	delete(object, JobFingerprintKey)
+	canonical, err := json.Marshal(object)
+	if err != nil {
+		return data
+	}
+	return string(canonical)
+}
+
+// CreateJobsIdempotent creates the jobs of a batch that do not exist yet
+// Every job is fingerprinted, and the fingerprint is embedded in its data where possible. Jobs in
+// the account the batch is sent to (scoped further by opts.Jobs) with the same fingerprint count
+// as already created, so running the same batch again after a failure or timeout creates only the
+// missing jobs. Identical jobs in one batch are matched one for one. The remaining jobs are sent in one request carrying an
+// idempotency key, so servers that support it can deduplicate a retried request as well.
+// All jobs must belong to one account; split mixed batches with BatchCreateJobsPerAccount first.
+func (c *Client) CreateJobsIdempotent(ctx context.Context, jobs []JobRequestBody, opts IdempotentCreateOptions, reqOpts ...RequestOption) (*IdempotentCreateResult, error) {
+	if err := checkAccounts("", "", jobs); err != nil {
+		return nil, err
+	}
+
+	fingerprints := make([]string, len(jobs))
+	for i, job := range jobs {
+		fingerprints[i] = JobFingerprint(job)
+	}
+
+	result := &IdempotentCreateResult{IdempotencyKey: opts.IdempotencyKey}
+
+	// Scan the account the batch will be sent to, so the scan and the create cannot diverge
+	account, err := c.resolveAccountID(jobs, collectRequestOptions(reqOpts).accountID)
+	if err != nil {
+		return nil, err
+	}
+	params := opts.Jobs
+	if account != "" {
+		accountID, err := strconv.ParseInt(account, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account ID %q: %w", account, err)
+		}
+		if params.AccountID != 0 && params.AccountID != accountID {
+			return nil, fmt.Errorf("%w: jobs are scanned in account %d but created in account %d", ErrAccountMismatch, params.AccountID, accountID)
+		}
+		params.AccountID = accountID
+	}
+	existing, err := c.AllJobs(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list existing jobs: %w", err)
+	}
+	created := map[string][]Job{}
+	changed := map[string][]Job{}
+	for _, job := range existing {
+		fingerprint := ExistingJobFingerprint(job)
+		created[fingerprint] = append(created[fingerprint], job)
+		// The embedded fingerprint only tells which batch job a modified job was created from
+		if embedded := embeddedJobFingerprint(job); embedded != "" && embedded != fingerprint {
+			changed[embedded] = append(changed[embedded], job)
+		}
+	}
+
+	var sent []string
+	for i, job := range jobs {
+		if matches := created[fingerprints[i]]; len(matches) > 0 {
+			result.Skipped = append(result.Skipped, matches[0])
+			created[fingerprints[i]] = matches[1:]
+			continue
+		}
+		result.Changed = append(result.Changed, changed[fingerprints[i]]...)
+		delete(changed, fingerprints[i])
+		result.Created = append(result.Created, WithJobFingerprint(job))
+		sent = append(sent, fingerprints[i])
+	}
+	if len(result.Created) == 0 {
+		return result, nil
+	}
+	if result.IdempotencyKey == "" {
+		result.IdempotencyKey = batchIdempotencyKey(sent)
+	}
+
+	reqOpts = append([]RequestOption{WithIdempotencyKey(result.IdempotencyKey)}, reqOpts...)
+	result.Response, err = c.batchCreateJobs(ctx, result.Created, reqOpts...)
+	return result, err
+}
+
+// batchIdempotencyKey derives an idempotency key from the fingerprints of the jobs sent
+func batchIdempotencyKey(fingerprints []string) string {
+	sorted := append([]string(nil), fingerprints...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+	return "jobs-" + hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,52 @@
+package scheduler0_go_client
+
+import "context"
+
+// JobIterator walks every job matching ListJobsParams, fetching pages on demand
+//
+//	it := client.NewJobIterator(ctx, params)
+//	for it.Next() {
+//		job := it.Job()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type JobIterator struct {
+	pager pager[Job]
+}
+
+// NewJobIterator creates an iterator over all jobs matching params
+// params.Limit is used as the page size and params.Offset as the starting offset.
+func (c *Client) NewJobIterator(ctx context.Context, params ListJobsParams) *JobIterator {
+	if params.Limit <= 0 {
+		params.Limit = defaultPageSize
+	}
+	fetch := func(ctx context.Context, offset int) ([]Job, int, error) {
+		params.Offset = offset
+		result, err := c.listJobs(ctx, params)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Data.Jobs, result.Data.Total, nil
+	}
+	return &JobIterator{pager: newPager(ctx, params.Limit, params.Offset, fetch)}
+}
+
+// Next advances to the next job, fetching the next page when needed
+// It returns false when all jobs have been read or an error occurred.
+func (it *JobIterator) Next() bool {
+	return it.pager.next()
+}
+
+// Job returns the job at the current position
+func (it *JobIterator) Job() Job {
+	return it.pager.current
+}
+
+// Err returns the error that stopped iteration, if any
+func (it *JobIterator) Err() error {
+	return it.pager.err
+}
+
+// AllJobs collects every job matching params
+func (c *Client) AllJobs(ctx context.Context, params ListJobsParams) ([]Job, error) {
+	return c.NewJobIterator(ctx, params).pager.collect()
+}
@@ -1,9 +1,16 @@
 package scheduler0_go_client
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // ListJobs retrieves all jobs with optional query parameters
-func (c *Client) ListJobs(params ListJobsParams) (*PaginatedJobsResponse, error) {
+func (c *Client) ListJobs(params ListJobsParams, opts ...RequestOption) (*PaginatedJobsResponse, error) {
+	return c.listJobs(context.Background(), params, opts...)
+}
+
+func (c *Client) listJobs(ctx context.Context, params ListJobsParams, opts ...RequestOption) (*PaginatedJobsResponse, error) {
 	queryParams := map[string]string{
 		"limit":  fmt.Sprintf("%d", params.Limit),
 		"offset": fmt.Sprintf("%d", params.Offset),
@@ -21,20 +28,19 @@ func (c *Client) ListJobs(params ListJobsParams) (*PaginatedJobsResponse, error)
 		queryParams["orderByDirection"] = params.OrderByDirection
 	}
 
-	var accountIDOverride string
-	if params.AccountID > 0 {
-		accountIDOverride = fmt.Sprintf("%d", params.AccountID)
+	opts, err := listAccountOptions(params.AccountID, opts)
+	if err != nil {
+		return nil, err
 	}
-	req, err := c.newRequestWithQuery("GET", "/jobs", nil, queryParams, accountIDOverride)
+	req, err := c.newRequestWithQuery("GET", "/jobs", nil, queryParams, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var result PaginatedJobsResponse
-	err = c.do(req, &result)
+	err = c.doContext(ctx, req, &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
-
@@ -0,0 +1,68 @@
+package scheduler0_go_client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// maxFireTimes caps how many fire times are expanded for a single job and window
+const maxFireTimes = 100000
+
+// ErrTooManyFireTimes is returned when a schedule fires more often than maxFireTimes within a window
+var ErrTooManyFireTimes = errors.New("too many fire times in window")
+
+// specParser accepts the six-field (with seconds) specs used by Scheduler0 as well as
+// standard five-field specs and descriptors such as @hourly
+var specParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// ExpectedFireTimes returns the times spec fires within [from, to], evaluated in timezone
+// An empty timezone is treated as UTC.
+func ExpectedFireTimes(spec, timezone string, from, to time.Time) ([]time.Time, error) {
+	schedule, err := specParser.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec %q: %w", spec, err)
+	}
+
+	loc := time.UTC
+	if timezone != "" {
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+	}
+
+	var times []time.Time
+	for next := schedule.Next(from.In(loc).Add(-time.Nanosecond)); !next.IsZero() && !next.After(to); next = schedule.Next(next) {
+		if len(times) == maxFireTimes {
+			return nil, fmt.Errorf("%w: spec %q between %s and %s", ErrTooManyFireTimes, spec, FormatTime(from), FormatTime(to))
+		}
+		times = append(times, next)
+	}
+	return times, nil
+}
+
+// JobFireTimes returns the times job fires within [from, to], clipped to its StartDate and EndDate
+func JobFireTimes(job Job, from, to time.Time) ([]time.Time, error) {
+	start, err := job.ParsedStartDate()
+	if err != nil {
+		return nil, err
+	}
+	end, err := job.ParsedEndDate()
+	if err != nil {
+		return nil, err
+	}
+
+	if !start.IsZero() && start.After(from) {
+		from = start
+	}
+	if !end.IsZero() && end.Before(to) {
+		to = end
+	}
+	if to.Before(from) {
+		return nil, nil
+	}
+	return ExpectedFireTimes(job.Spec, job.Timezone, from, to)
+}
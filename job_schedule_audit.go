@@ -0,0 +1,293 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ScheduleAuditOptions configures a missed-run audit
+type ScheduleAuditOptions struct {
+	From      time.Time     // Start of the audited window (defaults to 24 hours before To, but not before the job's StartDate)
+	To        time.Time     // End of the audited window (defaults to now)
+	Tolerance time.Duration // Delay after a fire time before an execution counts as late (defaults to 1 minute)
+	ClockSkew time.Duration // How early an execution may start and still match a fire time (defaults to 5 seconds)
+}
+
+// LateExecution is an execution that started more than the tolerance after its fire time
+type LateExecution struct {
+	Expected  time.Time
+	Actual    time.Time
+	Delay     time.Duration
+	Execution Execution
+}
+
+// DuplicateExecution is a fire time that was matched by more than one execution
+type DuplicateExecution struct {
+	Expected   time.Time
+	Executions []Execution
+}
+
+// JobScheduleAudit reports how a job's executions line up with its schedule
+type JobScheduleAudit struct {
+	JobID         int64
+	Spec          string
+	Timezone      string
+	Expected      []time.Time          // Fire times computed from Spec and Timezone
+	Missed        []time.Time          // Fire times with no execution
+	Late          []LateExecution      // Executions beyond the tolerance
+	Duplicates    []DuplicateExecution // Fire times with more than one execution
+	Unexpected    []Execution          // Executions that match no fire time
+	LastExecution time.Time            // Job.LastExecutionDate
+	Stale         bool                 // LastExecution is older than the latest fire time that should have run
+	Err           error                // Set when the job could not be audited, e.g. an invalid spec
+}
+
+// HasProblems reports whether the audit found missed, late, duplicate or stale runs
+func (a *JobScheduleAudit) HasProblems() bool {
+	return a.Err != nil || a.Stale || len(a.Missed) > 0 || len(a.Late) > 0 || len(a.Duplicates) > 0
+}
+
+// ScheduleAuditReport is the result of auditing many jobs
+type ScheduleAuditReport struct {
+	From time.Time
+	To   time.Time
+	Jobs []JobScheduleAudit
+}
+
+// Problems returns the audits that found missed, late, duplicate or stale runs
+func (r *ScheduleAuditReport) Problems() []JobScheduleAudit {
+	var problems []JobScheduleAudit
+	for _, audit := range r.Jobs {
+		if audit.HasProblems() {
+			problems = append(problems, audit)
+		}
+	}
+	return problems
+}
+
+// AuditJobSchedules audits every active job matching params over the window in opts
+// Errors for individual jobs are recorded on their audit; listing errors abort the audit.
+func (c *Client) AuditJobSchedules(ctx context.Context, params ListJobsParams, opts ScheduleAuditOptions) (*ScheduleAuditReport, error) {
+	opts = opts.withDefaults()
+	report := &ScheduleAuditReport{From: opts.From, To: opts.To}
+
+	it := c.NewJobIterator(ctx, params)
+	for it.Next() {
+		job := it.Job()
+		if job.Status != "" && job.Status != "active" {
+			continue
+		}
+		audit, err := c.AuditJobSchedule(ctx, job, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			audit = &JobScheduleAudit{JobID: job.ID, Spec: job.Spec, Timezone: job.Timezone, Err: err}
+		}
+		report.Jobs = append(report.Jobs, *audit)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// AuditJobSchedule fetches the executions of job over the window in opts and audits them
+// A window in which the job fires more than 100000 times returns ErrTooManyFireTimes before any
+// execution is fetched.
+func (c *Client) AuditJobSchedule(ctx context.Context, job Job, opts ScheduleAuditOptions) (*JobScheduleAudit, error) {
+	opts, err := opts.forJob(job)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := auditFireTimes(job, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	executions, err := c.AllExecutions(ctx, ListExecutionsParams{
+		StartDate: FormatTime(opts.From.Add(-opts.ClockSkew)),
+		EndDate:   FormatTime(opts.To.Add(opts.Tolerance)),
+		JobID:     job.ID,
+		AccountID: job.AccountID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions for job %d: %w", job.ID, err)
+	}
+	return auditJobExecutions(job, executions, opts, expected), nil
+}
+
+// AuditJobExecutions compares executions against the fire times of job without calling the API
+func AuditJobExecutions(job Job, executions []Execution, opts ScheduleAuditOptions) (*JobScheduleAudit, error) {
+	opts, err := opts.forJob(job)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := auditFireTimes(job, opts)
+	if err != nil {
+		return nil, err
+	}
+	return auditJobExecutions(job, executions, opts, expected), nil
+}
+
+// auditFireTimes expands the fire times of job over the audited window
+func auditFireTimes(job Job, opts ScheduleAuditOptions) ([]time.Time, error) {
+	expected, err := JobFireTimes(job, opts.From, opts.To)
+	if errors.Is(err, ErrTooManyFireTimes) {
+		return nil, fmt.Errorf("job %d fires more than %d times between %s and %s, audit a shorter window: %w",
+			job.ID, maxFireTimes, FormatTime(opts.From), FormatTime(opts.To), ErrTooManyFireTimes)
+	}
+	return expected, err
+}
+
+// auditJobExecutions matches executions to the expected fire times of job
+func auditJobExecutions(job Job, executions []Execution, opts ScheduleAuditOptions, expected []time.Time) *JobScheduleAudit {
+	audit := &JobScheduleAudit{
+		JobID:    job.ID,
+		Spec:     job.Spec,
+		Timezone: job.Timezone,
+		Expected: expected,
+	}
+	audit.LastExecution, _ = job.ParsedLastExecutionDate()
+
+	// Match each run (latest version per UniqueID) to the fire time it was scheduled for
+	matched := make([][]Execution, len(expected))
+	actualTimes := make([][]time.Time, len(expected))
+	for _, execution := range latestExecutionVersions(executions, job.ID) {
+		actual := executionRunTime(execution)
+		i := scheduledFireTime(expected, execution, actual.Add(opts.ClockSkew))
+		if i < 0 || actual.IsZero() {
+			audit.Unexpected = append(audit.Unexpected, execution)
+			continue
+		}
+		matched[i] = append(matched[i], execution)
+		actualTimes[i] = append(actualTimes[i], actual)
+	}
+
+	now := time.Now()
+	var latestDue time.Time
+	for i, fireTime := range expected {
+		due := !fireTime.Add(opts.Tolerance).After(now)
+		if due {
+			latestDue = fireTime
+		}
+
+		switch len(matched[i]) {
+		case 0:
+			if due {
+				audit.Missed = append(audit.Missed, fireTime)
+			}
+			continue
+		case 1:
+		default:
+			audit.Duplicates = append(audit.Duplicates, DuplicateExecution{Expected: fireTime, Executions: matched[i]})
+		}
+
+		first := 0
+		for j := range actualTimes[i] {
+			if actualTimes[i][j].Before(actualTimes[i][first]) {
+				first = j
+			}
+		}
+		if delay := actualTimes[i][first].Sub(fireTime); delay > opts.Tolerance {
+			audit.Late = append(audit.Late, LateExecution{
+				Expected:  fireTime,
+				Actual:    actualTimes[i][first],
+				Delay:     delay,
+				Execution: matched[i][first],
+			})
+		}
+	}
+
+	if !latestDue.IsZero() && !audit.LastExecution.IsZero() {
+		audit.Stale = audit.LastExecution.Add(opts.ClockSkew).Before(latestDue)
+	}
+	return audit
+}
+
+// scheduledFireTime returns the index of the fire time in expected that a run at actual was
+// scheduled for, or -1 if it precedes them all
+// That is the latest fire time at or before actual. A run delayed past the following fire time
+// still names the fire time after its own as NextExecutionDatetime, so it is matched to the
+// fire time preceding that one rather than to the following slot.
+func scheduledFireTime(expected []time.Time, execution Execution, actual time.Time) int {
+	i := sort.Search(len(expected), func(i int) bool {
+		return expected[i].After(actual)
+	}) - 1
+	if next, err := execution.ParsedNextExecutionDatetime(); err == nil && !next.IsZero() {
+		j := sort.Search(len(expected), func(j int) bool {
+			return !expected[j].Before(next)
+		}) - 1
+		if j < i {
+			return j
+		}
+	}
+	return i
+}
+
+// forJob applies the defaults of opts and moves From up to the job's StartDate
+// Jobs are never audited from their StartDate by default, since a long-running job would
+// expand and fetch its whole history.
+func (opts ScheduleAuditOptions) forJob(job Job) (ScheduleAuditOptions, error) {
+	opts = opts.withDefaults()
+	start, err := job.ParsedStartDate()
+	if err != nil {
+		return opts, err
+	}
+	if start.After(opts.From) {
+		opts.From = start
+	}
+	return opts, nil
+}
+
+func (opts ScheduleAuditOptions) withDefaults() ScheduleAuditOptions {
+	if opts.To.IsZero() {
+		opts.To = time.Now()
+	}
+	if opts.From.IsZero() {
+		opts.From = opts.To.Add(-24 * time.Hour)
+	}
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = time.Minute
+	}
+	if opts.ClockSkew <= 0 {
+		opts.ClockSkew = 5 * time.Second
+	}
+	return opts
+}
+
+// latestExecutionVersions keeps the highest ExecutionVersion of each run of jobID (0 for any job), in input order
+func latestExecutionVersions(executions []Execution, jobID int64) []Execution {
+	index := map[string]int{}
+	var latest []Execution
+	for _, execution := range executions {
+		if jobID != 0 && execution.JobID != 0 && execution.JobID != jobID {
+			continue
+		}
+		key := execution.UniqueID
+		if key == "" {
+			key = fmt.Sprintf("id:%d", execution.ID)
+		}
+		if i, ok := index[key]; ok {
+			if execution.ExecutionVersion > latest[i].ExecutionVersion {
+				latest[i] = execution
+			}
+			continue
+		}
+		index[key] = len(latest)
+		latest = append(latest, execution)
+	}
+	return latest
+}
+
+// executionRunTime returns when an execution ran, falling back to its creation time
+func executionRunTime(execution Execution) time.Time {
+	if t, err := execution.ParsedLastExecutionDatetime(); err == nil && !t.IsZero() {
+		return t
+	}
+	t, _ := execution.ParsedDateCreated()
+	return t
+}
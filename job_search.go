@@ -0,0 +1,292 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JobFilter reports whether a job matches a search criterion
+type JobFilter func(Job) bool
+
+// JobStatusIs matches jobs whose status is one of statuses
+func JobStatusIs(statuses ...string) JobFilter {
+	return func(job Job) bool {
+		for _, status := range statuses {
+			if job.Status == status {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// JobExecutorIs matches jobs assigned to one of the given executor IDs
+func JobExecutorIs(executorIDs ...int64) JobFilter {
+	return func(job Job) bool {
+		if job.ExecutorID == nil {
+			return false
+		}
+		for _, id := range executorIDs {
+			if *job.ExecutorID == id {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// JobSpecMatches matches jobs whose cron spec matches pattern
+func JobSpecMatches(pattern *regexp.Regexp) JobFilter {
+	return func(job Job) bool {
+		return pattern.MatchString(job.Spec)
+	}
+}
+
+// JobTimezoneIs matches jobs in one of the given timezones
+func JobTimezoneIs(timezones ...string) JobFilter {
+	return func(job Job) bool {
+		for _, tz := range timezones {
+			if job.Timezone == tz {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// JobCreatedBy matches jobs created by one of the given users
+func JobCreatedBy(users ...string) JobFilter {
+	return func(job Job) bool {
+		for _, user := range users {
+			if job.CreatedBy == user {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// JobCreatedBetween matches jobs whose DateCreated falls within [from, to]
+// A zero bound leaves that side of the range open.
+func JobCreatedBetween(from, to time.Time) JobFilter {
+	return jobDateBetween(func(job Job) string { return job.DateCreated }, from, to)
+}
+
+// JobStartDateBetween matches jobs whose StartDate falls within [from, to]
+// A zero bound leaves that side of the range open.
+func JobStartDateBetween(from, to time.Time) JobFilter {
+	return jobDateBetween(func(job Job) string { return job.StartDate }, from, to)
+}
+
+// JobLastExecutedBetween matches jobs whose LastExecutionDate falls within [from, to]
+// A zero bound leaves that side of the range open.
+func JobLastExecutedBetween(from, to time.Time) JobFilter {
+	return jobDateBetween(func(job Job) string { return job.LastExecutionDate }, from, to)
+}
+
+func jobDateBetween(field func(Job) string, from, to time.Time) JobFilter {
+	return func(job Job) bool {
+		t, err := ParseTime(field(job))
+		if err != nil || t.IsZero() {
+			return false
+		}
+		if !from.IsZero() && t.Before(from) {
+			return false
+		}
+		if !to.IsZero() && t.After(to) {
+			return false
+		}
+		return true
+	}
+}
+
+// JobDataPathEquals matches jobs whose Data is JSON and holds value at path
+// path is dot separated with numeric segments indexing arrays, e.g. "recipients.0".
+// Payloads written by CreateTypedJob are unwrapped so paths are relative to the payload.
+func JobDataPathEquals(path string, value interface{}) JobFilter {
+	expected, ok := normalizeJSONValue(value)
+	return func(job Job) bool {
+		if !ok {
+			return false
+		}
+		actual, found := lookupJobDataPath(job.Data, path)
+		return found && reflect.DeepEqual(actual, expected)
+	}
+}
+
+// JobDataPathExists matches jobs whose Data is JSON and has a value at path
+func JobDataPathExists(path string) JobFilter {
+	return func(job Job) bool {
+		_, found := lookupJobDataPath(job.Data, path)
+		return found
+	}
+}
+
+func lookupJobDataPath(data, path string) (interface{}, bool) {
+	if data == "" {
+		return nil, false
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		return nil, false
+	}
+
+	// Unwrap payloads stored by EncodeJobData, leaving plain data with a "payload" key alone
+	if payload, ok := jobDataEnvelopePayload(doc); ok {
+		if err := json.Unmarshal(payload, &doc); err != nil {
+			return nil, false
+		}
+	}
+
+	current := doc
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			current = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// jobDataEnvelopePayload returns the payload of doc if it has the type, version and payload keys
+// written by EncodeJobData
+func jobDataEnvelopePayload(doc interface{}) (json.RawMessage, bool) {
+	object, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	for _, key := range []string{"type", "version", "payload"} {
+		if _, ok := object[key]; !ok {
+			return nil, false
+		}
+	}
+	payload, err := json.Marshal(object["payload"])
+	if err != nil {
+		return nil, false
+	}
+	return payload, true
+}
+
+// normalizeJSONValue round-trips value through JSON so it compares equal to decoded data
+func normalizeJSONValue(value interface{}) (interface{}, bool) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, false
+	}
+	return normalized, true
+}
+
+// MatchJobs returns the jobs that match every filter
+func MatchJobs(jobs []Job, filters ...JobFilter) []Job {
+	var matched []Job
+	for _, job := range jobs {
+		if matchesAll(job, filters) {
+			matched = append(matched, job)
+		}
+	}
+	return matched
+}
+
+func matchesAll(job Job, filters []JobFilter) bool {
+	for _, filter := range filters {
+		if !filter(job) {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchJobs iterates over all jobs matching params and returns those that match every filter
+func (c *Client) SearchJobs(ctx context.Context, params ListJobsParams, filters ...JobFilter) ([]Job, error) {
+	var matched []Job
+	it := c.NewJobIterator(ctx, params)
+	for it.Next() {
+		if job := it.Job(); matchesAll(job, filters) {
+			matched = append(matched, job)
+		}
+	}
+	return matched, it.Err()
+}
+
+// JobCache keeps a local snapshot of jobs so repeated searches avoid refetching
+type JobCache struct {
+	client    *Client
+	params    ListJobsParams
+	ttl       time.Duration
+	mu        sync.Mutex
+	jobs      []Job
+	fetchedAt time.Time
+	version   uint64 // Bumped by Invalidate so a fetch started before it is not cached
+}
+
+// NewJobCache creates a cache over the jobs matching params
+// The snapshot is refreshed once it is older than ttl; a ttl of 0 keeps it until Invalidate is called.
+func NewJobCache(client *Client, params ListJobsParams, ttl time.Duration) *JobCache {
+	return &JobCache{client: client, params: params, ttl: ttl}
+}
+
+// Search returns cached jobs that match every filter, refreshing the snapshot if it is stale
+func (jc *JobCache) Search(ctx context.Context, filters ...JobFilter) ([]Job, error) {
+	jobs, err := jc.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return MatchJobs(jobs, filters...), nil
+}
+
+// Invalidate drops the cached snapshot so the next Search refetches
+func (jc *JobCache) Invalidate() {
+	jc.mu.Lock()
+	defer jc.mu.Unlock()
+	jc.jobs = nil
+	jc.fetchedAt = time.Time{}
+	jc.version++
+}
+
+// snapshot returns the cached jobs, refetching them without holding the lock if they are stale
+// Concurrent searches on a stale cache may each fetch; the last fetch to finish is kept.
+func (jc *JobCache) snapshot(ctx context.Context) ([]Job, error) {
+	jc.mu.Lock()
+	stale := jc.fetchedAt.IsZero() || (jc.ttl > 0 && time.Since(jc.fetchedAt) > jc.ttl)
+	jobs, version := jc.jobs, jc.version
+	jc.mu.Unlock()
+	if !stale {
+		return jobs, nil
+	}
+
+	jobs, err := jc.client.AllJobs(ctx, jc.params)
+	if err != nil {
+		return nil, err
+	}
+
+	jc.mu.Lock()
+	defer jc.mu.Unlock()
+	if jc.version == version {
+		jc.jobs = jobs
+		jc.fetchedAt = time.Now()
+	}
+	return jobs, nil
+}
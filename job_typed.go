@@ -0,0 +1,142 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrPayloadVersionMismatch is returned when a job payload was written with a different schema version
+var ErrPayloadVersionMismatch = errors.New("job payload version mismatch")
+
+// ErrPayloadTypeMismatch is returned when a job payload was written by a different payload type
+var ErrPayloadTypeMismatch = errors.New("job payload type mismatch")
+
+// VersionedPayload can be implemented by payload types to stamp a schema version into Job.Data
+// DecodeJobData rejects payloads whose stored version differs from PayloadVersion.
+type VersionedPayload interface {
+	PayloadVersion() int
+}
+
+// NamedPayload can be implemented by payload types to stamp a type name into Job.Data
+// Receivers use the name to route payloads, see JobDataType.
+type NamedPayload interface {
+	PayloadType() string
+}
+
+// jobDataEnvelope is the JSON shape stored in Job.Data for typed payloads
+// Type and Version are always written, even when empty, so the envelope can be told apart from
+// user data that merely has a "payload" key.
+type jobDataEnvelope struct {
+	Type    string          `json:"type"`
+	Version int             `json:"version"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// EncodeJobData encodes a typed payload into the string stored in Job.Data
+func EncodeJobData[T any](payload T) (string, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	envelope := jobDataEnvelope{
+		Type:    payloadType[T](),
+		Version: payloadVersion[T](),
+		Payload: raw,
+	}
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode job payload: %w", err)
+	}
+	return string(data), nil
+}
+
+// DecodeJobData decodes Job.Data written by EncodeJobData or CreateTypedJob into T
+func DecodeJobData[T any](job Job) (T, error) {
+	return decodeJobDataString[T](job.Data)
+}
+
+func decodeJobDataString[T any](data string) (T, error) {
+	var payload T
+	if data == "" {
+		return payload, errors.New("job has no data")
+	}
+
+	var envelope jobDataEnvelope
+	if err := json.Unmarshal([]byte(data), &envelope); err != nil {
+		return payload, fmt.Errorf("failed to decode job data: %w", err)
+	}
+	if envelope.Payload == nil {
+		return payload, errors.New("job data is not a typed payload")
+	}
+
+	// A T that declares a type only decodes payloads stamped with that type, not untyped ones
+	if expected := payloadType[T](); expected != "" && envelope.Type != expected {
+		return payload, fmt.Errorf("%w: expected %q, got %q", ErrPayloadTypeMismatch, expected, envelope.Type)
+	}
+	if expected := payloadVersion[T](); expected != 0 && envelope.Version != expected {
+		return payload, fmt.Errorf("%w: expected version %d, got %d", ErrPayloadVersionMismatch, expected, envelope.Version)
+	}
+
+	if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+		return payload, fmt.Errorf("failed to decode job payload: %w", err)
+	}
+	return payload, nil
+}
+
+// JobDataType returns the payload type name stored in Job.Data, or "" if it has none
+func JobDataType(job Job) string {
+	var envelope jobDataEnvelope
+	if err := json.Unmarshal([]byte(job.Data), &envelope); err != nil {
+		return ""
+	}
+	return envelope.Type
+}
+
+// CreateTypedJob encodes payload into body.Data and creates the job
+// Pass WithAccount to override the client's default account ID
+func CreateTypedJob[T any](ctx context.Context, c *Client, body *JobRequestBody, payload T, opts ...RequestOption) (*BatchJobResponse, error) {
+	data, err := EncodeJobData(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	job := *body
+	job.Data = data
+	return c.batchCreateJobs(ctx, []JobRequestBody{job}, opts...)
+}
+
+// PayloadTypeOf returns the type name declared by T through NamedPayload, or "" if T is not named
+// Unlike calling PayloadType on a zero T, it is safe for pointer types with value receivers.
+func PayloadTypeOf[T any]() string {
+	return payloadType[T]()
+}
+
+// payloadProbe returns a non-nil pointer to a zero value of T, or of T's element type when T is
+// a pointer, so declaration methods with value or pointer receivers can be called on it
+func payloadProbe[T any]() any {
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return reflect.New(t).Interface()
+}
+
+// payloadVersion returns the schema version declared by T, or 0 if T is not versioned
+func payloadVersion[T any]() int {
+	if v, ok := payloadProbe[T]().(VersionedPayload); ok {
+		return v.PayloadVersion()
+	}
+	return 0
+}
+
+// payloadType returns the type name declared by T, or "" if T is not named
+func payloadType[T any]() string {
+	if v, ok := payloadProbe[T]().(NamedPayload); ok {
+		return v.PayloadType()
+	}
+	return ""
+}
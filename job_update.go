@@ -1,24 +1,45 @@
 package scheduler0_go_client
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // UpdateJob updates an existing job
-// accountIDOverride is optional - if provided, overrides the client's default account ID
-func (c *Client) UpdateJob(id string, body *JobUpdateRequestBody, accountIDOverride ...string) (*JobResponse, error) {
-	var accountID string
-	if len(accountIDOverride) > 0 {
-		accountID = accountIDOverride[0]
-	}
-	req, err := c.newRequest("PUT", fmt.Sprintf("/jobs/%s", id), body, accountID)
+// Pass WithAccount to override the client's default account ID
+func (c *Client) UpdateJob(id string, body *JobUpdateRequestBody, opts ...RequestOption) (*JobResponse, error) {
+	return c.updateJob(context.Background(), id, body, opts...)
+}
+
+func (c *Client) updateJob(ctx context.Context, id string, body *JobUpdateRequestBody, opts ...RequestOption) (*JobResponse, error) {
+	req, err := c.newRequest("PUT", fmt.Sprintf("/jobs/%s", id), body, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var result JobResponse
-	err = c.do(req, &result)
+	err = c.doContext(ctx, req, &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// jobUpdateBody returns an update request that sets every field of job, so a PUT built from a
+// fetched job only changes the fields modified on it
+func jobUpdateBody(job Job, modifiedBy string) *JobUpdateRequestBody {
+	return &JobUpdateRequestBody{
+		AccountID:      job.AccountID,
+		ProjectID:      job.ProjectID,
+		ExecutorID:     job.ExecutorID,
+		Data:           job.Data,
+		Spec:           job.Spec,
+		StartDate:      job.StartDate,
+		EndDate:        job.EndDate,
+		Timezone:       job.Timezone,
+		TimezoneOffset: job.TimezoneOffset,
+		RetryMax:       job.RetryMax,
+		Status:         job.Status,
+		ModifiedBy:     modifiedBy,
+	}
+}
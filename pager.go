@@ -0,0 +1,74 @@
+package scheduler0_go_client
+
+import "context"
+
+// defaultPageSize is used by iterators when no limit is given
+const defaultPageSize = 100
+
+// pageFetcher fetches the page starting at offset and reports the server's total, if known
+type pageFetcher[T any] func(ctx context.Context, offset int) ([]T, int, error)
+
+// pager walks an offset-paginated list endpoint, fetching pages on demand
+// It is shared by the exported iterators and the helpers that collect a whole listing.
+type pager[T any] struct {
+	ctx     context.Context
+	fetch   pageFetcher[T]
+	limit   int
+	offset  int
+	page    []T
+	index   int
+	current T
+	done    bool
+	err     error
+}
+
+// newPager creates a pager reading limit items per page from offset
+func newPager[T any](ctx context.Context, limit, offset int, fetch pageFetcher[T]) pager[T] {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	return pager[T]{ctx: ctx, fetch: fetch, limit: limit, offset: offset}
+}
+
+// next advances to the next item, fetching the next page when needed
+func (p *pager[T]) next() bool {
+	if p.err != nil {
+		return false
+	}
+
+	for p.index >= len(p.page) {
+		if p.done {
+			return false
+		}
+		if err := p.ctx.Err(); err != nil {
+			p.err = err
+			return false
+		}
+
+		page, total, err := p.fetch(p.ctx, p.offset)
+		if err != nil {
+			p.err = err
+			return false
+		}
+
+		p.page = page
+		p.index = 0
+		p.offset += len(page)
+		if len(page) < p.limit || (total > 0 && p.offset >= total) {
+			p.done = true
+		}
+	}
+
+	p.current = p.page[p.index]
+	p.index++
+	return true
+}
+
+// collect reads every remaining item
+func (p *pager[T]) collect() ([]T, error) {
+	var items []T
+	for p.next() {
+		items = append(items, p.current)
+	}
+	return items, p.err
+}
@@ -1,8 +1,8 @@
 package scheduler0_go_client
 
 // CreateProject creates a new project
-func (c *Client) CreateProject(body *ProjectRequestBody) (*ProjectResponse, error) {
-	req, err := c.newRequest("POST", "/projects", body)
+func (c *Client) CreateProject(body *ProjectRequestBody, opts ...RequestOption) (*ProjectResponse, error) {
+	req, err := c.newRequest("POST", "/projects", body, opts...)
 	if err != nil {
 		return nil, err
 	}
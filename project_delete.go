@@ -3,8 +3,8 @@ package scheduler0_go_client
 import "fmt"
 
 // DeleteProject deletes a project by ID
-func (c *Client) DeleteProject(id int64, body *ProjectDeleteRequestBody) error {
-	req, err := c.newRequest("DELETE", fmt.Sprintf("/projects/%d", id), body)
+func (c *Client) DeleteProject(id int64, body *ProjectDeleteRequestBody, opts ...RequestOption) error {
+	req, err := c.newRequest("DELETE", fmt.Sprintf("/projects/%d", id), body, opts...)
 	if err != nil {
 		return err
 	}
@@ -3,8 +3,8 @@ package scheduler0_go_client
 import "fmt"
 
 // GetProject retrieves a single project by ID
-func (c *Client) GetProject(id int64) (*ProjectResponse, error) {
-	req, err := c.newRequest("GET", fmt.Sprintf("/projects/%d", id), nil)
+func (c *Client) GetProject(id int64, opts ...RequestOption) (*ProjectResponse, error) {
+	req, err := c.newRequest("GET", fmt.Sprintf("/projects/%d", id), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
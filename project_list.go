@@ -3,7 +3,7 @@ package scheduler0_go_client
 import "fmt"
 
 // ListProjects retrieves all projects with optional query parameters
-func (c *Client) ListProjects(params ListProjectsParams) (*PaginatedProjectsResponse, error) {
+func (c *Client) ListProjects(params ListProjectsParams, opts ...RequestOption) (*PaginatedProjectsResponse, error) {
 	queryParams := map[string]string{
 		"limit":  fmt.Sprintf("%d", params.Limit),
 		"offset": fmt.Sprintf("%d", params.Offset),
@@ -16,12 +16,11 @@ func (c *Client) ListProjects(params ListProjectsParams) (*PaginatedProjectsResp
 		queryParams["orderByDirection"] = params.OrderByDirection
 	}
 
-	var accountIDOverride string
-	if params.AccountID > 0 {
-		accountIDOverride = fmt.Sprintf("%d", params.AccountID)
+	opts, err := listAccountOptions(params.AccountID, opts)
+	if err != nil {
+		return nil, err
 	}
-
-	req, err := c.newRequestWithQuery("GET", "/projects", nil, queryParams, accountIDOverride)
+	req, err := c.newRequestWithQuery("GET", "/projects", nil, queryParams, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3,8 +3,8 @@ package scheduler0_go_client
 import "fmt"
 
 // UpdateProject updates an existing project
-func (c *Client) UpdateProject(id int64, body *ProjectUpdateRequestBody) (*ProjectResponse, error) {
-	req, err := c.newRequest("PUT", fmt.Sprintf("/projects/%d", id), body)
+func (c *Client) UpdateProject(id int64, body *ProjectUpdateRequestBody, opts ...RequestOption) (*ProjectResponse, error) {
+	req, err := c.newRequest("PUT", fmt.Sprintf("/projects/%d", id), body, opts...)
 	if err != nil {
 		return nil, err
 	}
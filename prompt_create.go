@@ -2,8 +2,8 @@ package scheduler0_go_client
 
 // CreateJobFromPrompt creates job configurations from an AI prompt
 // This endpoint requires credits and uses AI to generate job configurations
-func (c *Client) CreateJobFromPrompt(body *PromptJobRequest) ([]PromptJobResponse, error) {
-	req, err := c.newRequest("POST", "/prompt", body)
+func (c *Client) CreateJobFromPrompt(body *PromptJobRequest, opts ...RequestOption) ([]PromptJobResponse, error) {
+	req, err := c.newRequest("POST", "/prompt", body, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,358 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit bounds the requests of a client, an operation or an account
+type Limit struct {
+	Rate        float64 // Requests per second (0 for unlimited)
+	Burst       int     // Requests that may be sent at once (defaults to Rate rounded up)
+	MaxInFlight int     // Requests awaiting a response at the same time (0 for unlimited)
+}
+
+// WithLimit applies limit to every request of the client
+func WithLimit(limit Limit) ClientOption {
+	return func(c *Client) {
+		c.requestLimits().global = newLimiter(limit)
+	}
+}
+
+// WithOperationLimit applies limit to the requests of one operation, in addition to WithLimit
+// Operations are named by HTTP method and top-level resource, e.g. "POST /jobs" or
+// "GET /executions"; "GET /jobs" covers both listing and fetching jobs.
+func WithOperationLimit(operation string, limit Limit) ClientOption {
+	return func(c *Client) {
+		if method, resource, ok := strings.Cut(operation, " "); ok {
+			operation = strings.ToUpper(method) + " /" + strings.Trim(resource, "/")
+		}
+		c.requestLimits().operations[operation] = newLimiter(limit)
+	}
+}
+
+// WithAccountLimit applies limit to the requests sent for accountID, in addition to WithLimit
+func WithAccountLimit(accountID string, limit Limit) ClientOption {
+	return func(c *Client) {
+		c.requestLimits().accounts[accountID] = newLimiter(limit)
+	}
+}
+
+// WithDefaultAccountLimit applies limit separately to each account without a WithAccountLimit
+func WithDefaultAccountLimit(limit Limit) ClientOption {
+	return func(c *Client) {
+		l := limit
+		c.requestLimits().accountDefault = &l
+	}
+}
+
+// Bounds of the pause after a 429 response without Retry-After
+const (
+	minThrottleBackoff = 500 * time.Millisecond
+	maxThrottleBackoff = 30 * time.Second
+)
+
+// clientLimits holds the limiters of a client and its pause after 429 responses
+// Tenant clients have their own, chained to their parent's so both apply.
+type clientLimits struct {
+	parent         *clientLimits
+	global         *limiter
+	operations     map[string]*limiter
+	accounts       map[string]*limiter
+	accountDefault *Limit
+
+	mu          sync.Mutex
+	pausedUntil time.Time     // Set when the server answers 429
+	backoff     time.Duration // Pause after the last 429 without Retry-After
+}
+
+func newClientLimits(parent *clientLimits) *clientLimits {
+	return &clientLimits{parent: parent, operations: map[string]*limiter{}, accounts: map[string]*limiter{}}
+}
+
+// requestLimits returns the limits of c, creating them for clients not built with NewClient
+func (c *Client) requestLimits() *clientLimits {
+	if c.limits == nil {
+		c.limits = newClientLimits(nil)
+	}
+	return c.limits
+}
+
+// limiter combines a rate limit with a bound on requests in flight
+type limiter struct {
+	bucket *tokenBucket
+	slots  chan struct{}
+}
+
+func newLimiter(limit Limit) *limiter {
+	l := &limiter{}
+	if limit.Rate > 0 {
+		l.bucket = newTokenBucket(limit.Rate, limit.Burst)
+	}
+	if limit.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l
+}
+
+// acquire waits for the rate limit and a free slot; the slot is held until release
+// If ctx is done while waiting for a slot, the token already taken is returned.
+func (l *limiter) acquire(ctx context.Context) (release func(), err error) {
+	if l.bucket != nil {
+		if err := l.bucket.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if l.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		l.cancelToken()
+		return nil, ctx.Err()
+	}
+}
+
+// cancelToken returns the token taken by an acquire whose request was not sent
+func (l *limiter) cancelToken() {
+	if l.bucket != nil {
+		l.bucket.cancel()
+	}
+}
+
+// limitGrant records the limiters a request passed so its outcome can adjust them
+type limitGrant struct {
+	limits   *clientLimits
+	limiters []*limiter
+	releases []func()
+}
+
+// acquire waits until req may be sent under every limit that applies to it, including those
+// of parent clients
+// The most specific limits are taken first, so a busy account or operation does not hold
+// global slots while it waits for its own, and a tenant does not hold its parent's slots.
+func (ls *clientLimits) acquire(req *http.Request, operation string) (*limitGrant, error) {
+	ctx := req.Context()
+	for level := ls; level != nil; level = level.parent {
+		if err := level.waitPause(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	grant := &limitGrant{limits: ls}
+	accountID := req.Header.Get("X-Account-ID")
+	for level := ls; level != nil; level = level.parent {
+		for _, l := range []*limiter{level.account(accountID), level.operations[operation], level.global} {
+			if l == nil {
+				continue
+			}
+			release, err := l.acquire(ctx)
+			if err != nil {
+				grant.abort()
+				return nil, err
+			}
+			grant.limiters = append(grant.limiters, l)
+			grant.releases = append(grant.releases, release)
+		}
+	}
+	return grant, nil
+}
+
+// account returns the limiter of accountID, creating it from the default account limit if needed
+func (ls *clientLimits) account(accountID string) *limiter {
+	if accountID == "" {
+		return nil
+	}
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	l, ok := ls.accounts[accountID]
+	if !ok && ls.accountDefault != nil {
+		l = newLimiter(*ls.accountDefault)
+		ls.accounts[accountID] = l
+	}
+	return l
+}
+
+// waitPause blocks while requests are paused after a 429 response
+func (ls *clientLimits) waitPause(ctx context.Context) error {
+	ls.mu.Lock()
+	delay := time.Until(ls.pausedUntil)
+	ls.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// abort releases the grant of a request that will not be sent and returns its tokens
+func (g *limitGrant) abort() {
+	g.release()
+	for _, l := range g.limiters {
+		l.cancelToken()
+	}
+}
+
+func (g *limitGrant) release() {
+	for _, release := range g.releases {
+		release()
+	}
+	g.releases = nil
+}
+
+// observe adapts the limits to the response of the request
+// A 429 pauses all requests of the client for Retry-After, or an exponential backoff without it,
+// and halves the rates of the limits the request passed. Successful responses restore them
+// gradually. This applies whether or not any limit is configured.
+func (g *limitGrant) observe(resp *http.Response) {
+	ls := g.limits
+	if resp.StatusCode != http.StatusTooManyRequests {
+		if resp.StatusCode < 400 {
+			ls.mu.Lock()
+			ls.backoff = 0
+			ls.mu.Unlock()
+			for _, l := range g.limiters {
+				if l.bucket != nil {
+					l.bucket.speedUp()
+				}
+			}
+		}
+		return
+	}
+
+	for _, l := range g.limiters {
+		if l.bucket != nil {
+			l.bucket.slowDown()
+		}
+	}
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	pause, ok := retryAfter(resp.Header.Get("Retry-After"))
+	if !ok {
+		ls.backoff = min(max(ls.backoff*2, minThrottleBackoff), maxThrottleBackoff)
+		pause = ls.backoff
+	}
+	if until := time.Now().Add(pause); until.After(ls.pausedUntil) {
+		ls.pausedUntil = until
+	}
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// operationName names the operation of req for WithOperationLimit, e.g. "POST /jobs"
+func (c *Client) operationName(req *http.Request) string {
+	prefix := path.Join("/", c.BaseURL.Path, "api", c.Version) + "/"
+	resource := strings.TrimPrefix(req.URL.Path, prefix)
+	if i := strings.IndexByte(resource, '/'); i >= 0 {
+		resource = resource[:i]
+	}
+	return req.Method + " /" + resource
+}
+
+// tokenBucket allows rate requests per second with bursts of up to burst requests
+type tokenBucket struct {
+	mu     sync.Mutex
+	limit  float64 // Configured rate; rate drops below it after 429 responses
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = int(math.Max(1, math.Ceil(rate)))
+	}
+	return &tokenBucket{limit: rate, rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// refill adds the tokens accrued since the last call; b.mu must be held
+func (b *tokenBucket) refill() {
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// slowDown halves the rate, down to a tenth of the configured rate
+func (b *tokenBucket) slowDown() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	b.rate = math.Max(b.rate/2, b.limit/10)
+}
+
+// speedUp raises a reduced rate by a tenth of the configured rate
+func (b *tokenBucket) speedUp() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rate < b.limit {
+		b.refill()
+		b.rate = math.Min(b.rate+b.limit/10, b.limit)
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// Wait blocks until a request may be sent or ctx is done
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
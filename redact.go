@@ -0,0 +1,200 @@
+package scheduler0_go_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// redactedValue replaces secrets in formatted output
+const redactedValue = "[REDACTED]"
+
+// redactedKeys are the JSON keys MarshalRedacted masks, compared case-insensitively
+var redactedKeys = map[string]bool{
+	"apisecret":      true,
+	"cloudapisecret": true,
+	"webhooksecret":  true,
+	"password":       true,
+	"secret":         true,
+}
+
+// Method-free copies of the sensitive types, used to format them without recursing into Format
+type (
+	credentialFields  Credential
+	credentialsFields Credentials
+	executorFields    Executor
+)
+
+// clientFields is the formatted view of a Client; it lists the exported fields so the lock is not copied
+type clientFields struct {
+	BaseURL    *url.URL
+	HTTPClient *http.Client
+	APIKey     string
+	APISecret  string
+	Version    string
+	Username   string
+	Password   string
+	AccountID  string
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// Format prints the credential with APISecret redacted for every verb
+func (c Credential) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, c.redacted(), "Credential")
+}
+
+// LogValue logs the credential with APISecret redacted
+func (c Credential) LogValue() slog.Value {
+	return slog.AnyValue(c.redacted())
+}
+
+// Reveal formats the credential including its secret
+// Use it only where printing the secret is intended.
+func (c Credential) Reveal() string {
+	return fmt.Sprintf("%+v", credentialFields(c))
+}
+
+func (c Credential) redacted() credentialFields {
+	c.APISecret = redact(c.APISecret)
+	return credentialFields(c)
+}
+
+// Format prints the executor with CloudAPISecret and WebhookSecret redacted for every verb
+func (e Executor) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, e.redacted(), "Executor")
+}
+
+// LogValue logs the executor with CloudAPISecret and WebhookSecret redacted
+func (e Executor) LogValue() slog.Value {
+	return slog.AnyValue(e.redacted())
+}
+
+// Reveal formats the executor including its secrets
+// Use it only where printing the secrets is intended.
+func (e Executor) Reveal() string {
+	return fmt.Sprintf("%+v", executorFields(e))
+}
+
+func (e Executor) redacted() executorFields {
+	e.CloudAPISecret = redact(e.CloudAPISecret)
+	e.WebhookSecret = redact(e.WebhookSecret)
+	return executorFields(e)
+}
+
+// Format prints the client with APISecret and Password redacted for every verb
+func (c *Client) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, c.redacted(), "Client")
+}
+
+// LogValue logs the client's base URL, version, account and identities without secrets
+func (c *Client) LogValue() slog.Value {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	attrs := []slog.Attr{
+		slog.String("version", c.Version),
+		slog.String("accountId", c.AccountID),
+		slog.String("apiKey", c.APIKey),
+		slog.String("apiSecret", redact(c.APISecret)),
+		slog.String("username", c.Username),
+		slog.String("password", redact(c.Password)),
+	}
+	if c.BaseURL != nil {
+		attrs = append([]slog.Attr{slog.String("baseUrl", c.BaseURL.String())}, attrs...)
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Reveal formats the client including its secrets
+// Use it only where printing the secrets is intended.
+func (c *Client) Reveal() string {
+	return fmt.Sprintf("%+v", c.fields())
+}
+
+func (c *Client) redacted() *clientFields {
+	fields := c.fields()
+	if fields != nil {
+		fields.APISecret = redact(fields.APISecret)
+		fields.Password = redact(fields.Password)
+	}
+	return fields
+}
+
+func (c *Client) fields() *clientFields {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return &clientFields{
+		BaseURL:    c.BaseURL,
+		HTTPClient: c.HTTPClient,
+		APIKey:     c.APIKey,
+		APISecret:  c.APISecret,
+		Version:    c.Version,
+		Username:   c.Username,
+		Password:   c.Password,
+		AccountID:  c.AccountID,
+	}
+}
+
+// formatRedacted prints a redacted copy, naming it after the original type for %#v
+func formatRedacted(f fmt.State, verb rune, redacted any, typeName string) {
+	out := fmt.Sprintf(fmt.FormatString(f, verb), redacted)
+	if verb == 'v' && f.Flag('#') {
+		copyName := strings.TrimPrefix(fmt.Sprintf("%T", redacted), "*")
+		out = strings.Replace(out, copyName, "scheduler0_go_client."+typeName, 1)
+	}
+	io.WriteString(f, out)
+}
+
+// MarshalRedacted encodes v as JSON with secret fields replaced by "[REDACTED]"
+// Fields are matched by JSON key at any depth (apiSecret, cloudApiSecret, webhookSecret,
+// password and secret), so it works for single resources, responses and lists alike.
+// Regular json.Marshal is unaffected and still includes secrets.
+func MarshalRedacted(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// UseNumber keeps int64 IDs exact
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var tree any
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return json.Marshal(redactJSON(tree))
+}
+
+func redactJSON(node any) any {
+	switch value := node.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if secret, ok := child.(string); ok && redactedKeys[strings.ToLower(key)] {
+				value[key] = redact(secret)
+				continue
+			}
+			value[key] = redactJSON(child)
+		}
+		return value
+	case []any:
+		for i, child := range value {
+			value[i] = redactJSON(child)
+		}
+		return value
+	default:
+		return node
+	}
+}
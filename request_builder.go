@@ -10,48 +10,12 @@ import (
 	"reflect"
 )
 
-func (c *Client) newRequest(method, endpoint string, body interface{}, accountIDOverride ...string) (*http.Request, error) {
-	versionPrefix := fmt.Sprintf("/api/%s/", c.Version)
-
-	rel := &url.URL{Path: path.Join(fmt.Sprintf("%s%s", c.BaseURL.Path, versionPrefix), endpoint)}
-	u := c.BaseURL.ResolveReference(rel)
-
-	var buf bytes.Buffer
-	if body != nil {
-		err := json.NewEncoder(&buf).Encode(body)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	req, err := http.NewRequest(method, u.String(), &buf)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	// Set authentication based on client type
-	if c.Username != "" && c.Password != "" {
-		// Basic Auth for peer communication
-		req.SetBasicAuth(c.Username, c.Password)
-		req.Header.Set("X-Peer", "cmd")
-	} else if c.APIKey != "" && c.APISecret != "" {
-		// API Key + Secret authentication
-		req.Header.Set("X-API-Key", c.APIKey)
-		req.Header.Set("X-Secret-Key", c.APISecret)
-	}
-
-	// Add account ID based on override/body/client default preferences
-	accountID := c.resolveAccountID(body, accountIDOverride)
-	if accountID != "" {
-		req.Header.Set("X-Account-ID", accountID)
-	}
-
-	return req, nil
+func (c *Client) newRequest(method, endpoint string, body interface{}, opts ...RequestOption) (*http.Request, error) {
+	return c.newRequestWithQuery(method, endpoint, body, nil, opts...)
 }
 
-func (c *Client) newRequestWithQuery(method, endpoint string, body interface{}, queryParams map[string]string, accountIDOverride ...string) (*http.Request, error) {
+func (c *Client) newRequestWithQuery(method, endpoint string, body interface{}, queryParams map[string]string, opts ...RequestOption) (*http.Request, error) {
+	options := collectRequestOptions(opts)
 	versionPrefix := fmt.Sprintf("/api/%s/", c.Version)
 
 	rel := &url.URL{Path: path.Join(fmt.Sprintf("%s%s", c.BaseURL.Path, versionPrefix), endpoint)}
@@ -79,21 +43,14 @@ func (c *Client) newRequestWithQuery(method, endpoint string, body interface{},
 		return nil, err
 	}
 
+	req = options.apply(req, true)
 	req.Header.Set("Content-Type", "application/json")
 
-	// Set authentication based on client type
-	if c.Username != "" && c.Password != "" {
-		// Basic Auth for peer communication
-		req.SetBasicAuth(c.Username, c.Password)
-		req.Header.Set("X-Peer", "cmd")
-	} else if c.APIKey != "" && c.APISecret != "" {
-		// API Key + Secret authentication
-		req.Header.Set("X-API-Key", c.APIKey)
-		req.Header.Set("X-Secret-Key", c.APISecret)
-	}
-
 	// Add account ID based on override/body/client default preferences
-	accountID := c.resolveAccountID(body, accountIDOverride)
+	accountID, err := c.resolveAccountID(body, options.accountID)
+	if err != nil {
+		return nil, err
+	}
 
 	if accountID != "" {
 		req.Header.Set("X-Account-ID", accountID)
@@ -102,16 +59,72 @@ func (c *Client) newRequestWithQuery(method, endpoint string, body interface{},
 	return req, nil
 }
 
-func (c *Client) resolveAccountID(body interface{}, accountIDOverride []string) string {
-	if len(accountIDOverride) > 0 && accountIDOverride[0] != "" {
-		return accountIDOverride[0]
+// setAuthHeaders sets authentication based on client type
+// Credentials are resolved per request so providers and SetAPIKey can rotate them while requests
+// are in flight. do calls it with the context the request is sent with, so providers see the
+// caller's cancellation and deadline.
+func (c *Client) setAuthHeaders(req *http.Request) error {
+	creds, err := c.currentCredentials(req.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	if creds.Username != "" && creds.Password != "" {
+		// Basic Auth for peer communication
+		req.SetBasicAuth(creds.Username, creds.Password)
+		req.Header.Set("X-Peer", "cmd")
+	} else if creds.APIKey != "" && creds.APISecret != "" {
+		// API Key + Secret authentication
+		req.Header.Set("X-API-Key", creds.APIKey)
+		req.Header.Set("X-Secret-Key", creds.APISecret)
+	}
+	return nil
+}
+
+// resolveAccountID picks the account of a request: the WithAccount override, then the body, then the client default
+// Tenant clients and clients with strict account scoping reject requests naming conflicting accounts.
+func (c *Client) resolveAccountID(body interface{}, override string) (string, error) {
+	if c.pinnedAccount != "" {
+		return c.pinnedAccount, checkAccounts(c.pinnedAccount, override, body)
+	}
+	if c.strictAccounts {
+		if err := checkAccounts("", override, body); err != nil {
+			return "", err
+		}
+	}
+
+	if override != "" {
+		return override, nil
 	}
 
 	if accountID := extractAccountIDFromBody(body); accountID != "" {
-		return accountID
+		return accountID, nil
 	}
 
-	return c.AccountID
+	return c.AccountID, nil
+}
+
+// checkAccounts returns ErrAccountMismatch unless every account ID named by override and body
+// (including every element of a batch) equals want, or each other when want is empty
+func checkAccounts(want, override string, body interface{}) error {
+	var accountIDs []string
+	if override != "" {
+		accountIDs = append(accountIDs, override)
+	}
+	if body != nil {
+		accountIDs = extractAccountIDsFromValue(reflect.ValueOf(body), accountIDs)
+	}
+
+	for _, accountID := range accountIDs {
+		if want == "" {
+			want = accountID
+			continue
+		}
+		if accountID != want {
+			return fmt.Errorf("%w: request targets accounts %s and %s", ErrAccountMismatch, want, accountID)
+		}
+	}
+	return nil
 }
 
 func extractAccountIDFromBody(body interface{}) string {
@@ -150,6 +163,32 @@ func extractAccountIDFromValue(val reflect.Value) string {
 	return ""
 }
 
+// extractAccountIDsFromValue appends every account ID found in val, including all slice elements
+func extractAccountIDsFromValue(val reflect.Value, accountIDs []string) []string {
+	if !val.IsValid() {
+		return accountIDs
+	}
+
+	switch val.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if !val.IsNil() {
+			return extractAccountIDsFromValue(val.Elem(), accountIDs)
+		}
+	case reflect.Struct:
+		field := val.FieldByName("AccountID")
+		if field.IsValid() && field.CanInterface() {
+			if accountID := accountIDValue(field); accountID != "" {
+				accountIDs = append(accountIDs, accountID)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			accountIDs = extractAccountIDsFromValue(val.Index(i), accountIDs)
+		}
+	}
+	return accountIDs
+}
+
 func accountIDValue(field reflect.Value) string {
 	switch field.Kind() {
 	case reflect.String:
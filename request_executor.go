@@ -1,6 +1,7 @@
 package scheduler0_go_client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,11 +9,45 @@ import (
 )
 
 func (c *Client) do(req *http.Request, v interface{}) error {
+	state, _ := stateOf(req.Context())
+	if state.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), state.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	if state.authenticate {
+		if err := c.setAuthHeaders(req); err != nil {
+			return err
+		}
+	}
+
+	// Check the circuit breaker first so an open breaker fails fast instead of waiting for limits
+	var call *circuitCall
+	if c.breakers != nil {
+		var err error
+		if call, err = c.breakers.allow(c.circuitName(req)); err != nil {
+			return err
+		}
+	}
+
+	grant, err := c.waitLimits(req)
+	if err != nil {
+		call.cancel()
+		return err
+	}
+	if grant != nil {
+		defer grant.release()
+	}
+
 	resp, err := c.HTTPClient.Do(req)
+	call.done(req.Context(), resp, err)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
+	if grant != nil {
+		grant.observe(resp)
+	}
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
@@ -25,3 +60,20 @@ func (c *Client) do(req *http.Request, v interface{}) error {
 	return nil
 }
 
+// waitLimits blocks until req may be sent under the client's rate and concurrency limits
+// The returned grant, if any, must be released once the response has been read.
+func (c *Client) waitLimits(req *http.Request) (*limitGrant, error) {
+	if c.limits == nil {
+		return nil, nil
+	}
+	return c.limits.acquire(req, c.operationName(req))
+}
+
+// doContext executes the request bound to ctx so it is aborted when ctx is cancelled
+// A WithTimeout option of the request is carried over to ctx, and credentials are resolved with ctx.
+func (c *Client) doContext(ctx context.Context, req *http.Request, v interface{}) error {
+	if state, ok := stateOf(req.Context()); ok {
+		ctx = context.WithValue(ctx, requestStateKey{}, state)
+	}
+	return c.do(req.WithContext(ctx), v)
+}
@@ -0,0 +1,144 @@
+package scheduler0_go_client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers set by WithIdempotencyKey and WithRequestID
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	RequestIDHeader      = "X-Request-ID"
+)
+
+// authHeaders carry credentials and are only ever set from the client's own credentials
+var authHeaders = []string{"Authorization", "X-API-Key", "X-Secret-Key", "X-Peer"}
+
+// RequestOption configures a single API call
+type RequestOption func(*requestOptions)
+
+// requestOptions is the per-call configuration applied in newRequest
+type requestOptions struct {
+	accountID string
+	timeout   time.Duration
+	header    http.Header
+}
+
+// requestStateKey carries the requestState of a request on its context until the request is sent
+type requestStateKey struct{}
+
+// requestState is what do needs to know about a request beyond its URL and headers
+type requestState struct {
+	timeout      time.Duration // WithTimeout duration, 0 for none
+	authenticate bool          // Set the auth headers from the client's credentials when sending
+}
+
+// WithAccount sends the request on behalf of accountID
+// It overrides the client's default account and any AccountID set in the request body.
+// List methods return ErrAccountMismatch if their params name a different AccountID.
+// An empty accountID leaves the account unchanged.
+func WithAccount(accountID string) RequestOption {
+	return func(o *requestOptions) {
+		if accountID != "" {
+			o.accountID = accountID
+		}
+	}
+}
+
+// WithTimeout bounds the call, including rate limit waits, to timeout
+// It applies in addition to any deadline of the caller's context and the HTTP client's timeout.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithHeader sets an extra request header, replacing earlier values of key
+// The Content-Type and X-Account-ID headers set by the client take precedence. Authentication
+// headers (Authorization, X-API-Key, X-Secret-Key and X-Peer) are ignored in every auth mode,
+// including on clients without credentials, so credentials never come from request options.
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		for _, header := range authHeaders {
+			if http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(header) {
+				return
+			}
+		}
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// WithIdempotencyKey sends key in the Idempotency-Key header so the server can deduplicate retries
+// An empty key sends no header.
+func WithIdempotencyKey(key string) RequestOption {
+	if key == "" {
+		return func(*requestOptions) {}
+	}
+	return WithHeader(IdempotencyKeyHeader, key)
+}
+
+// WithRequestID sends id in the X-Request-ID header to correlate the call with server logs
+// An empty id sends no header.
+func WithRequestID(id string) RequestOption {
+	if id == "" {
+		return func(*requestOptions) {}
+	}
+	return WithHeader(RequestIDHeader, id)
+}
+
+func collectRequestOptions(opts []RequestOption) requestOptions {
+	var o requestOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// apply sets the extra headers of o on req and attaches its timeout, and whether do should
+// authenticate it, to the request context
+func (o requestOptions) apply(req *http.Request, authenticate bool) *http.Request {
+	for key, values := range o.header {
+		req.Header[key] = values
+	}
+	if o.timeout > 0 || authenticate {
+		state := requestState{timeout: o.timeout, authenticate: authenticate}
+		req = req.WithContext(context.WithValue(req.Context(), requestStateKey{}, state))
+	}
+	return req
+}
+
+// stateOf returns the requestState attached to ctx, if any
+func stateOf(ctx context.Context) (requestState, bool) {
+	state, ok := ctx.Value(requestStateKey{}).(requestState)
+	return state, ok
+}
+
+// accountOverride returns a WithAccount option for accountID, or a no-op option for 0
+func accountOverride(accountID int64) RequestOption {
+	if accountID <= 0 {
+		return WithAccount("")
+	}
+	return WithAccount(strconv.FormatInt(accountID, 10))
+}
+
+// listAccountOptions puts the AccountID of list params in front of opts as a WithAccount override
+// A WithAccount option naming a different account is an ErrAccountMismatch rather than an override,
+// so params.AccountID is never silently replaced.
+func listAccountOptions(accountID int64, opts []RequestOption) ([]RequestOption, error) {
+	if accountID <= 0 {
+		return opts, nil
+	}
+	want := strconv.FormatInt(accountID, 10)
+	if override := collectRequestOptions(opts).accountID; override != "" && override != want {
+		return nil, fmt.Errorf("%w: params target account %s, WithAccount %s", ErrAccountMismatch, want, override)
+	}
+	return append([]RequestOption{WithAccount(want)}, opts...), nil
+}
@@ -1,12 +1,12 @@
 package scheduler0_go_client
 
 // RestoreDatabase initiates a restore from a backup file
-func (c *Client) RestoreDatabase(backupPath string) (*BackupRestoreResponse, error) {
+func (c *Client) RestoreDatabase(backupPath string, opts ...RequestOption) (*BackupRestoreResponse, error) {
 	reqBody := RestoreRequest{
 		BackupPath: backupPath,
 	}
 
-	req, err := c.newRequest("POST", "/cluster/restore", reqBody, "")
+	req, err := c.newRequest("POST", "/cluster/restore", reqBody, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,148 @@
+package scheduler0_go_client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// ErrAccountMismatch is returned when a request scoped to one account targets another
+var ErrAccountMismatch = errors.New("account mismatch")
+
+// TenantClient is a Client pinned to a single account
+// Every request carries the tenant's account ID; account ID overrides and request bodies naming a
+// different account are rejected with ErrAccountMismatch instead of being sent. All methods of
+// Client are available on it, but neither ForAccount nor assigning the exported AccountID field
+// (on the tenant or its embedded Client) re-pins it to another account.
+type TenantClient struct {
+	*Client
+}
+
+type tenantConfig struct {
+	apiKey      string
+	apiSecret   string
+	credentials CredentialsProvider
+	rate        float64
+	burst       int
+}
+
+// TenantOption configures a TenantClient
+type TenantOption func(*tenantConfig)
+
+// WithTenantAPIKey authenticates the tenant with its own API key and secret
+func WithTenantAPIKey(apiKey, apiSecret string) TenantOption {
+	return func(cfg *tenantConfig) {
+		cfg.apiKey = apiKey
+		cfg.apiSecret = apiSecret
+	}
+}
+
+// WithTenantCredentialsProvider authenticates the tenant with credentials from provider
+func WithTenantCredentialsProvider(provider CredentialsProvider) TenantOption {
+	return func(cfg *tenantConfig) {
+		cfg.credentials = provider
+	}
+}
+
+// WithTenantRateLimit limits the tenant to rate requests per second with bursts of up to burst
+func WithTenantRateLimit(rate float64, burst int) TenantOption {
+	return func(cfg *tenantConfig) {
+		cfg.rate = rate
+		cfg.burst = burst
+	}
+}
+
+// ForAccount returns a client pinned to accountID that shares c's HTTP client and transport
+// Without tenant options it authenticates like c. Limits configured on c, such as WithLimit, and
+// pauses after 429 responses to c apply to the tenant's requests as well. The returned client
+// owns its rate limit and its own 429 backoff, so keep it for the lifetime of the tenant, or use
+// a TenantPool. accountID must be a positive numeric account ID. On a client that is itself
+// pinned, ForAccount only accepts the same account and returns ErrAccountMismatch otherwise.
+func (c *Client) ForAccount(accountID string, opts ...TenantOption) (*TenantClient, error) {
+	if id, err := strconv.ParseInt(accountID, 10, 64); err != nil || id <= 0 {
+		return nil, fmt.Errorf("invalid tenant account ID %q", accountID)
+	}
+	if c.pinnedAccount != "" && accountID != c.pinnedAccount {
+		return nil, fmt.Errorf("%w: client is pinned to account %s, not %s", ErrAccountMismatch, c.pinnedAccount, accountID)
+	}
+
+	var cfg tenantConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	c.mu.RLock()
+	tenant := &Client{
+		BaseURL:       c.BaseURL,
+		HTTPClient:    c.HTTPClient,
+		APIKey:        c.APIKey,
+		APISecret:     c.APISecret,
+		Version:       c.Version,
+		Username:      c.Username,
+		Password:      c.Password,
+		AccountID:     accountID,
+		credentials:   c.credentials,
+		limits:        newClientLimits(c.limits),
+		breakers:      c.breakers,
+		pinnedAccount: accountID,
+	}
+	c.mu.RUnlock()
+
+	if cfg.apiKey != "" {
+		// Basic auth takes precedence over API keys, so drop the parent's peer credentials
+		tenant.APIKey, tenant.APISecret = cfg.apiKey, cfg.apiSecret
+		tenant.Username, tenant.Password = "", ""
+		tenant.credentials = nil
+	}
+	if cfg.credentials != nil {
+		tenant.credentials = cfg.credentials
+	}
+	if cfg.rate > 0 {
+		tenant.limits.global = newLimiter(Limit{Rate: cfg.rate, Burst: cfg.burst})
+	}
+	return &TenantClient{Client: tenant}, nil
+}
+
+// TenantPool hands out one TenantClient per account, creating them on first use
+type TenantPool struct {
+	base      *Client
+	configure func(accountID string) []TenantOption
+
+	mu      sync.Mutex
+	tenants map[string]*TenantClient
+}
+
+// NewTenantPool creates a pool of tenant clients derived from base
+// configure may be nil; otherwise it returns the options for a tenant, e.g. its credentials
+// and rate limit looked up from the application's tenant store.
+func NewTenantPool(base *Client, configure func(accountID string) []TenantOption) *TenantPool {
+	return &TenantPool{base: base, configure: configure, tenants: map[string]*TenantClient{}}
+}
+
+// ForAccount returns the tenant client of accountID
+func (p *TenantPool) ForAccount(accountID string) (*TenantClient, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if tenant, ok := p.tenants[accountID]; ok {
+		return tenant, nil
+	}
+	var opts []TenantOption
+	if p.configure != nil {
+		opts = p.configure(accountID)
+	}
+	tenant, err := p.base.ForAccount(accountID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.tenants[accountID] = tenant
+	return tenant, nil
+}
+
+// Remove drops the tenant client of accountID, e.g. after its credentials changed
+func (p *TenantPool) Remove(accountID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.tenants, accountID)
+}
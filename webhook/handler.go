@@ -0,0 +1,305 @@
+// Package webhook receives requests sent by Scheduler0 webhook executors.
+//
+// Handler verifies the request signature with the executor's WebhookSecret, rejects stale and
+// replayed requests, decodes the delivered jobs and dispatches them to handlers registered by
+// job ID or payload type. Handler errors produce a 5xx response so Scheduler0 retries the delivery.
+//
+// Delivery is at least once: a job may be dispatched again when a retry arrives after the
+// handler's record of it has expired or was lost, so job handlers should be idempotent.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	scheduler0 "github.com/scheduler0/scheduler0-go-client"
+)
+
+// JobHandlerFunc processes a delivered job
+type JobHandlerFunc func(ctx context.Context, job scheduler0.Job) error
+
+// permanentError marks a failure that retrying will not fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the handler responds 422 and Scheduler0 does not retry
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// Handler is an http.Handler for webhook executor deliveries
+//
+// When one job of a multi-job delivery fails, the delivery is answered 500 and retried as a whole.
+// The jobs that succeeded are recorded in the NonceStore under the delivery's nonce and job ID,
+// and skipped when the retry arrives within the completion retention (see WithCompletionRetention).
+// Retries after that, retries that carry a new nonce, and retries reaching a replica that does not
+// share the NonceStore run those jobs again.
+type Handler struct {
+	secrets      []string
+	tolerance    time.Duration
+	retention    time.Duration
+	nonces       NonceStore
+	maxBodyBytes int64
+	now          func() time.Time
+
+	mu       sync.RWMutex
+	byJobID  map[int64]JobHandlerFunc
+	byType   map[string]JobHandlerFunc
+	fallback JobHandlerFunc
+}
+
+// Option configures a Handler
+type Option func(*Handler)
+
+// WithAdditionalSecrets accepts signatures made with older secrets while an executor secret is rotated
+func WithAdditionalSecrets(secrets ...string) Option {
+	return func(h *Handler) {
+		h.secrets = append(h.secrets, secrets...)
+	}
+}
+
+// WithTolerance sets how far the request timestamp may drift from now (defaults to 5 minutes)
+func WithTolerance(tolerance time.Duration) Option {
+	return func(h *Handler) {
+		h.tolerance = tolerance
+	}
+}
+
+// WithCompletionRetention sets how long the jobs completed within a delivery are remembered so a
+// retry of the delivery skips them (defaults to 24 hours)
+func WithCompletionRetention(retention time.Duration) Option {
+	return func(h *Handler) {
+		h.retention = retention
+	}
+}
+
+// WithNonceStore replaces the in-memory replay protection, e.g. with a store shared between replicas
+func WithNonceStore(store NonceStore) Option {
+	return func(h *Handler) {
+		h.nonces = store
+	}
+}
+
+// WithMaxBodyBytes limits the accepted request body size (defaults to 1 MiB)
+func WithMaxBodyBytes(n int64) Option {
+	return func(h *Handler) {
+		h.maxBodyBytes = n
+	}
+}
+
+// NewHandler creates a handler that verifies requests signed with secret
+func NewHandler(secret string, opts ...Option) *Handler {
+	h := &Handler{
+		secrets:      []string{secret},
+		tolerance:    5 * time.Minute,
+		retention:    24 * time.Hour,
+		nonces:       NewMemoryNonceStore(),
+		maxBodyBytes: 1 << 20,
+		now:          time.Now,
+		byJobID:      map[int64]JobHandlerFunc{},
+		byType:       map[string]JobHandlerFunc{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// HandleJob registers fn for deliveries of the job with jobID
+// Job ID handlers take precedence over payload type handlers.
+func (h *Handler) HandleJob(jobID int64, fn JobHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.byJobID[jobID] = fn
+}
+
+// HandleType registers fn for jobs whose payload type (see scheduler0.JobDataType) is payloadType
+func (h *Handler) HandleType(payloadType string, fn JobHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.byType[payloadType] = fn
+}
+
+// HandleDefault registers fn for jobs no other handler matches
+func (h *Handler) HandleDefault(fn JobHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = fn
+}
+
+// On registers a typed handler for payloads of type T, routed by T's PayloadType
+func On[T scheduler0.NamedPayload](h *Handler, fn func(ctx context.Context, job scheduler0.Job, payload T) error) {
+	h.HandleType(scheduler0.PayloadTypeOf[T](), typed(fn))
+}
+
+// OnJob registers a typed handler for the job with jobID
+func OnJob[T any](h *Handler, jobID int64, fn func(ctx context.Context, job scheduler0.Job, payload T) error) {
+	h.HandleJob(jobID, typed(fn))
+}
+
+func typed[T any](fn func(ctx context.Context, job scheduler0.Job, payload T) error) JobHandlerFunc {
+	return func(ctx context.Context, job scheduler0.Job) error {
+		payload, err := scheduler0.DecodeJobData[T](job)
+		if err != nil {
+			return Permanent(err)
+		}
+		return fn(ctx, job, payload)
+	}
+}
+
+// ServeHTTP verifies, decodes and dispatches a delivery
+// Responses: 401 for bad signatures or stale timestamps, 409 for replays, 400 for undecodable
+// bodies, 404 when no handler matches, 422 for permanent handler errors, 500 for other handler
+// errors (which Scheduler0 retries) and 200 on success. The nonce of a delivery answered 500 is
+// forgotten so its retry is not rejected as a replay. Dry-run requests sent by Client.TestExecutor
+// are signed separately, see scheduler0.SignWebhookDryRun, and answered 200 without dispatching.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, h.maxBodyBytes+1))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > h.maxBodyBytes {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	dryRun := r.Header.Get(scheduler0.WebhookDryRunHeader) == "true"
+	replayKey, status, err := h.verify(r, body, dryRun)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if dryRun {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	jobs, err := decodeJobs(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, job := range jobs {
+		fn := h.route(job)
+		if fn == nil {
+			http.Error(w, fmt.Sprintf("no handler for job %d", job.ID), http.StatusNotFound)
+			return
+		}
+
+		// Claim the job so an earlier attempt of this delivery that completed it is not repeated
+		jobKey := completionKey(replayKey, job.ID)
+		if h.nonces.Seen(jobKey, h.now().Add(h.retention)) {
+			continue
+		}
+		if err := fn(r.Context(), job); err != nil {
+			h.nonces.Forget(jobKey)
+			var permanent *permanentError
+			if errors.As(err, &permanent) {
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+			h.nonces.Forget(replayKey)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// completionKey is the NonceStore key recording that jobID was handled within a delivery
+func completionKey(replayKey string, jobID int64) string {
+	return replayKey + "/job/" + strconv.FormatInt(jobID, 10)
+}
+
+// verify checks the signature and timestamp of a request and claims its replay key
+// Dry runs must carry a dry-run signature and real deliveries a delivery signature.
+func (h *Handler) verify(r *http.Request, body []byte, dryRun bool) (string, int, error) {
+	signature := r.Header.Get(scheduler0.WebhookSignatureHeader)
+	if signature == "" {
+		return "", http.StatusUnauthorized, errors.New("missing signature")
+	}
+
+	timestamp, err := strconv.ParseInt(r.Header.Get(scheduler0.WebhookTimestampHeader), 10, 64)
+	if err != nil {
+		return "", http.StatusUnauthorized, errors.New("missing or invalid timestamp")
+	}
+	sent := time.Unix(timestamp, 0)
+	if drift := h.now().Sub(sent); drift > h.tolerance || drift < -h.tolerance {
+		return "", http.StatusUnauthorized, errors.New("timestamp outside tolerance")
+	}
+
+	nonce := r.Header.Get(scheduler0.WebhookNonceHeader)
+	verifySignature := scheduler0.VerifyWebhookSignature
+	if dryRun {
+		verifySignature = scheduler0.VerifyWebhookDryRunSignature
+	}
+	valid := false
+	for _, secret := range h.secrets {
+		if verifySignature(secret, signature, timestamp, nonce, body) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return "", http.StatusUnauthorized, errors.New("invalid signature")
+	}
+
+	// Fall back to the signature as the replay key when the sender does not supply a nonce
+	replayKey := nonce
+	if replayKey == "" {
+		replayKey = signature
+	}
+	if h.nonces.Seen(replayKey, sent.Add(h.tolerance)) {
+		return "", http.StatusConflict, errors.New("replayed request")
+	}
+	return replayKey, http.StatusOK, nil
+}
+
+func (h *Handler) route(job scheduler0.Job) JobHandlerFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if fn, ok := h.byJobID[job.ID]; ok {
+		return fn
+	}
+	if fn, ok := h.byType[scheduler0.JobDataType(job)]; ok {
+		return fn
+	}
+	return h.fallback
+}
+
+// decodeJobs accepts either a single job object or an array of jobs
+func decodeJobs(body []byte) ([]scheduler0.Job, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil, errors.New("empty body")
+	}
+
+	if trimmed[0] == '[' {
+		var jobs []scheduler0.Job
+		if err := json.Unmarshal(trimmed, &jobs); err != nil {
+			return nil, fmt.Errorf("invalid jobs payload: %w", err)
+		}
+		return jobs, nil
+	}
+
+	var job scheduler0.Job
+	if err := json.Unmarshal(trimmed, &job); err != nil {
+		return nil, fmt.Errorf("invalid job payload: %w", err)
+	}
+	return []scheduler0.Job{job}, nil
+}
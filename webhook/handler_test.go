@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	scheduler0 "github.com/scheduler0/scheduler0-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+type reportPayload struct {
+	Recipients []string `json:"recipients"`
+}
+
+func (reportPayload) PayloadType() string { return "report" }
+
+type alertPayload struct {
+	Level string `json:"level"`
+}
+
+func (alertPayload) PayloadType() string { return "alert" }
+
+// signedRequest builds a delivery signed the way a webhook executor signs it
+func signedRequest(t *testing.T, secret, nonce string, sent time.Time, jobs ...scheduler0.Job) *http.Request {
+	body, err := json.Marshal(jobs)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/hooks/scheduler0", strings.NewReader(string(body)))
+	req.Header.Set(scheduler0.WebhookTimestampHeader, strconv.FormatInt(sent.Unix(), 10))
+	req.Header.Set(scheduler0.WebhookNonceHeader, nonce)
+	req.Header.Set(scheduler0.WebhookSignatureHeader, scheduler0.SignWebhookPayload(secret, sent.Unix(), nonce, body))
+	return req
+}
+
+func TestHandlerDispatch(t *testing.T) {
+	data, err := scheduler0.EncodeJobData(reportPayload{Recipients: []string{"team@example.com"}})
+	assert.NoError(t, err)
+
+	handler := NewHandler("secret")
+	var recipients []string
+	On(handler, func(ctx context.Context, job scheduler0.Job, payload reportPayload) error {
+		recipients = payload.Recipients
+		return nil
+	})
+	handler.HandleJob(9, func(ctx context.Context, job scheduler0.Job) error {
+		return errors.New("target unavailable")
+	})
+	handler.HandleJob(10, func(ctx context.Context, job scheduler0.Job) error {
+		return Permanent(errors.New("bad input"))
+	})
+
+	now := time.Now()
+	cases := []struct {
+		name   string
+		req    *http.Request
+		status int
+	}{
+		{"typed payload", signedRequest(t, "secret", "n-1", now, scheduler0.Job{ID: 1, Data: data}), http.StatusOK},
+		{"replay", signedRequest(t, "secret", "n-1", now, scheduler0.Job{ID: 1, Data: data}), http.StatusConflict},
+		{"wrong secret", signedRequest(t, "other", "n-2", now, scheduler0.Job{ID: 1, Data: data}), http.StatusUnauthorized},
+		{"stale", signedRequest(t, "secret", "n-3", now.Add(-time.Hour), scheduler0.Job{ID: 1, Data: data}), http.StatusUnauthorized},
+		{"handler error", signedRequest(t, "secret", "n-4", now, scheduler0.Job{ID: 9}), http.StatusInternalServerError},
+		{"permanent error", signedRequest(t, "secret", "n-5", now, scheduler0.Job{ID: 10}), http.StatusUnprocessableEntity},
+		{"no handler", signedRequest(t, "secret", "n-6", now, scheduler0.Job{ID: 11}), http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, tc.req)
+		assert.Equal(t, tc.status, recorder.Code, tc.name)
+	}
+	assert.Equal(t, []string{"team@example.com"}, recipients)
+
+	// Unsigned requests are rejected
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/", strings.NewReader("{}")))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestHandlerPointerPayload(t *testing.T) {
+	data, err := scheduler0.EncodeJobData(&alertPayload{Level: "high"})
+	assert.NoError(t, err)
+
+	handler := NewHandler("secret")
+	var level string
+	On(handler, func(ctx context.Context, job scheduler0.Job, payload *alertPayload) error {
+		level = payload.Level
+		return nil
+	})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, signedRequest(t, "secret", "p-1", time.Now(), scheduler0.Job{ID: 1, Data: data}))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "high", level)
+}
+
+func TestHandlerSecretRotation(t *testing.T) {
+	handler := NewHandler("new-secret", WithAdditionalSecrets("old-secret"))
+	handler.HandleDefault(func(ctx context.Context, job scheduler0.Job) error { return nil })
+
+	for i, secret := range []string{"new-secret", "old-secret"} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, signedRequest(t, secret, fmt.Sprintf("n-%d", i), time.Now(), scheduler0.Job{ID: 1}))
+		assert.Equal(t, http.StatusOK, recorder.Code, secret)
+	}
+}
+
+func TestHandlerAcceptsExecutorDryRun(t *testing.T) {
+	handler := NewHandler("secret")
+	called := false
+	handler.HandleDefault(func(ctx context.Context, job scheduler0.Job) error {
+		called = true
+		return nil
+	})
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	client, err := scheduler0.NewClient("http://localhost", "v1", scheduler0.WithAPIKey("key", "secret"))
+	assert.NoError(t, err)
+	client.HTTPClient = server.Client()
+
+	executor := scheduler0.Executor{ID: 7, Type: scheduler0.ExecutorTypeWebhook, WebhookURL: server.URL, WebhookMethod: "POST", WebhookSecret: "secret"}
+	report, err := client.TestExecutor(context.Background(), executor)
+	assert.NoError(t, err)
+	assert.True(t, report.Success, report.Problems)
+	assert.Equal(t, http.StatusOK, report.StatusCode)
+	assert.NotNil(t, report.TLS)
+	assert.False(t, called)
+
+	executor.WebhookSecret = "wrong"
+	report, err = client.TestExecutor(context.Background(), executor)
+	assert.NoError(t, err)
+	assert.False(t, report.Success)
+	assert.Equal(t, http.StatusUnauthorized, report.StatusCode)
+}
+
+func TestHandlerRetryAfterFailure(t *testing.T) {
+	handler := NewHandler("secret")
+	attempts := 0
+	handler.HandleDefault(func(ctx context.Context, job scheduler0.Job) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	sent := time.Now()
+	statuses := []int{http.StatusInternalServerError, http.StatusOK, http.StatusConflict}
+	for _, want := range statuses {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, signedRequest(t, "secret", "retry-1", sent, scheduler0.Job{ID: 1}))
+		assert.Equal(t, want, recorder.Code)
+	}
+	assert.Equal(t, 2, attempts)
+}
+
+func TestHandlerRetrySkipsCompletedJobs(t *testing.T) {
+	handler := NewHandler("secret")
+	runs := map[int64]int{}
+	handler.HandleDefault(func(ctx context.Context, job scheduler0.Job) error {
+		runs[job.ID]++
+		if job.ID == 2 && runs[job.ID] == 1 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	// The retry of a partially failed delivery only runs the jobs that did not complete
+	sent := time.Now()
+	jobs := []scheduler0.Job{{ID: 1}, {ID: 2}, {ID: 3}}
+	for _, want := range []int{http.StatusInternalServerError, http.StatusOK} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, signedRequest(t, "secret", "multi-1", sent, jobs...))
+		assert.Equal(t, want, recorder.Code)
+	}
+	assert.Equal(t, map[int64]int{1: 1, 2: 2, 3: 1}, runs)
+
+	// Another delivery of the same jobs is dispatched again
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, signedRequest(t, "secret", "multi-2", sent, jobs...))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, map[int64]int{1: 2, 2: 3, 3: 2}, runs)
+}
+
+func TestHandlerDryRunHeaderIsSigned(t *testing.T) {
+	handler := NewHandler("secret")
+	called := false
+	handler.HandleDefault(func(ctx context.Context, job scheduler0.Job) error {
+		called = true
+		return nil
+	})
+
+	// Marking a signed delivery as a dry run invalidates its signature
+	req := signedRequest(t, "secret", "d-1", time.Now(), scheduler0.Job{ID: 1})
+	req.Header.Set(scheduler0.WebhookDryRunHeader, "true")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	// Stripping the header from a dry run does not turn it into a delivery
+	now := time.Now()
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`[{"id":1}]`))
+	req.Header.Set(scheduler0.WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(scheduler0.WebhookNonceHeader, "d-2")
+	req.Header.Set(scheduler0.WebhookSignatureHeader, scheduler0.SignWebhookDryRun("secret", now.Unix(), "d-2", []byte(`[{"id":1}]`)))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.False(t, called)
+}
@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+// NonceStore remembers delivery nonces to reject replays
+type NonceStore interface {
+	// Seen records nonce until expires and reports whether it was already recorded
+	Seen(nonce string, expires time.Time) bool
+	// Forget removes nonce so a delivery that failed and will be retried is accepted again
+	Forget(nonce string)
+}
+
+// MemoryNonceStore is an in-process NonceStore; expired nonces are pruned as new ones arrive
+type MemoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	now    func() time.Time
+}
+
+// NewMemoryNonceStore creates an empty in-memory nonce store
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{nonces: map[string]time.Time{}, now: time.Now}
+}
+
+// Seen records nonce until expires and reports whether it was already recorded
+func (s *MemoryNonceStore) Seen(nonce string, expires time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, expiry := range s.nonces {
+		if now.After(expiry) {
+			delete(s.nonces, key)
+		}
+	}
+
+	if _, ok := s.nonces[nonce]; ok {
+		return true
+	}
+	s.nonces[nonce] = expires
+	return false
+}
+
+// Forget removes nonce so a delivery that failed and will be retried is accepted again
+func (s *MemoryNonceStore) Forget(nonce string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.nonces, nonce)
+}
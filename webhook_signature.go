@@ -0,0 +1,68 @@
+package scheduler0_go_client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// Headers carrying the signature of webhook executor requests
+const (
+	WebhookSignatureHeader = "X-Scheduler0-Signature"
+	WebhookTimestampHeader = "X-Scheduler0-Timestamp"
+	WebhookNonceHeader     = "X-Scheduler0-Nonce"
+)
+
+// webhookSignaturePrefix identifies the signing algorithm in WebhookSignatureHeader
+const webhookSignaturePrefix = "sha256="
+
+// webhookDryRunDomain is prepended to the signed string of dry-run requests, so
+// WebhookDryRunHeader cannot be added to or removed from a signed request without breaking it
+const webhookDryRunDomain = "dry-run."
+
+// SignWebhookPayload signs a webhook request body with the executor's WebhookSecret
+// The signature is the hex HMAC-SHA256 of "<unix timestamp>.<nonce>.<body>", prefixed with "sha256=".
+// The scheme is defined by this client, not by the Scheduler0 server, which does not sign webhook
+// executor requests itself; senders and receivers must both use these functions, or a relay that
+// signs the same way, for the signature to verify.
+func SignWebhookPayload(secret string, timestamp int64, nonce string, body []byte) string {
+	return signWebhook(secret, "", timestamp, nonce, body)
+}
+
+// SignWebhookDryRun signs a dry-run request sent with WebhookDryRunHeader
+// The signed string is "dry-run.<unix timestamp>.<nonce>.<body>", so a dry-run signature is never
+// valid for a real delivery and the other way round.
+func SignWebhookDryRun(secret string, timestamp int64, nonce string, body []byte) string {
+	return signWebhook(secret, webhookDryRunDomain, timestamp, nonce, body)
+}
+
+// VerifyWebhookSignature reports whether signature matches the body signed with secret
+func VerifyWebhookSignature(secret, signature string, timestamp int64, nonce string, body []byte) bool {
+	return verifyWebhook(secret, "", signature, timestamp, nonce, body)
+}
+
+// VerifyWebhookDryRunSignature reports whether signature matches a dry-run request signed with secret
+func VerifyWebhookDryRunSignature(secret, signature string, timestamp int64, nonce string, body []byte) bool {
+	return verifyWebhook(secret, webhookDryRunDomain, signature, timestamp, nonce, body)
+}
+
+func signWebhook(secret, domain string, timestamp int64, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(domain))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write([]byte(nonce))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifyWebhook(secret, domain, signature string, timestamp int64, nonce string, body []byte) bool {
+	if !strings.HasPrefix(signature, webhookSignaturePrefix) {
+		return false
+	}
+	expected := signWebhook(secret, domain, timestamp, nonce, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}